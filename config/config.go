@@ -17,64 +17,210 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 )
 
-var ErrUsernamePasswordMissing = errors.New("username or password missing")
+var (
+	ErrUsernamePasswordMissing = errors.New("username or password missing")
+	ErrClientSecretMissing     = errors.New("client secret missing")
+	ErrBearerTokenMissing      = errors.New("exactly one of bearer token or bearer token file required")
+
+	ErrClientCertificateIncomplete = errors.New("tls.certFile and tls.keyFile must be specified together")
+	ErrTLSWithoutHTTPS             = errors.New("tls options require scheme https or a secure gRPC transport")
+)
+
+const (
+	AuthMechanismNone                  = "none"
+	AuthMechanismAPIKey                = "apiKey"
+	AuthMechanismWCSCreds              = "wcsCreds"
+	AuthMechanismOIDCClientCredentials = "oidcClientCredentials"
+	AuthMechanismBearerToken           = "bearerToken"
+)
 
 type Config struct {
-	// Host of the Weaviate instance.
+	// URL of the Weaviate instance (e.g. `https://gateway.internal/weaviate`),
+	// or its host and optional port and base path, in which case the scheme
+	// is taken from `scheme`.
 	Endpoint string `json:"endpoint" validate:"required"`
 
-	// Scheme of the Weaviate instance.
+	// Scheme of the Weaviate instance. Ignored if `endpoint` is a URL with
+	// a scheme.
 	Scheme string `json:"scheme" default:"https" validate:"inclusion=http|https"`
 
 	Auth Auth `json:"auth"`
 
-	// The class name as defined in the schema.
-	// A record will be saved under this class unless
-	// it has the `weaviate.class` metadata field.
-	Class string `json:"class" validate:"required"`
+	TLS TLS `json:"tls"`
+
+	HTTP HTTP `json:"http"`
+}
+
+// EndpointURL returns the URL of the Weaviate instance, without a trailing
+// slash.
+func (c Config) EndpointURL() (*url.URL, error) {
+	endpoint := c.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = c.Scheme + "://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("invalid endpoint %q: scheme must be http or https", c.Endpoint)
+	case u.Host == "":
+		return nil, fmt.Errorf("invalid endpoint %q: missing host", c.Endpoint)
+	case u.User != nil, u.RawQuery != "", u.Fragment != "":
+		return nil, fmt.Errorf("invalid endpoint %q: only scheme, host, port and path are allowed", c.Endpoint)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	return u, nil
+}
+
+type TLS struct {
+	// Path to a PEM file with the CA certificates used to verify the
+	// certificate of the Weaviate instance. The system CAs are used if empty.
+	CAFile string `json:"caFile"`
+	// Path to a PEM file with the client certificate, used for mutual TLS.
+	CertFile string `json:"certFile"`
+	// Path to a PEM file with the key of the client certificate.
+	KeyFile string `json:"keyFile"`
+	// Name used to verify the certificate of the Weaviate instance, if it
+	// differs from the host in `endpoint`.
+	ServerName string `json:"serverName"`
+	// Whether the certificate of the Weaviate instance should not be
+	// verified. Only use this for testing.
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+}
+
+func (t TLS) IsSet() bool {
+	return t != TLS{}
+}
+
+func (t TLS) Validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return ErrClientCertificateIncomplete
+	}
+	if t.InsecureSkipVerify && t.CAFile != "" {
+		return errors.New("only one of tls.caFile and tls.insecureSkipVerify can be specified")
+	}
+
+	return nil
+}
+
+type HTTP struct {
+	// Maximum time a request to Weaviate can take, including reading the
+	// response.
+	Timeout time.Duration `json:"timeout" default:"60s"`
+	// Maximum time to establish a connection to Weaviate.
+	ConnectTimeout time.Duration `json:"connectTimeout" default:"30s"`
+	// URL of the HTTP(S) proxy requests are sent through. If empty, the
+	// proxy is read from the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY`
+	// environment variables.
+	ProxyURL string `json:"proxyUrl"`
+	// Maximum number of idle connections kept open to Weaviate.
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost" default:"10" validate:"greater-than=0"`
+	// Whether request bodies should be compressed using gzip.
+	Gzip bool `json:"gzip"`
+}
+
+func (h HTTP) Validate() error {
+	if h.Timeout <= 0 {
+		return errors.New("http.timeout must be greater than 0")
+	}
+	if h.ConnectTimeout <= 0 {
+		return errors.New("http.connectTimeout must be greater than 0")
+	}
+	if h.ProxyURL != "" {
+		u, err := url.Parse(h.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid http.proxyUrl: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" || u.Host == "" {
+			return fmt.Errorf("invalid http.proxyUrl %q: expected scheme http, https or socks5 and a host", h.ProxyURL)
+		}
+	}
+
+	return nil
 }
 
 func (c *Config) Validate() error {
-	return c.Auth.Validate()
+	err := c.Auth.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.HTTP.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.EndpointURL()
+	if err != nil {
+		return err
+	}
+
+	return c.TLS.Validate()
 }
 
 type Auth struct {
 	// Mechanism specifies in which way the connector will authenticate to Weaviate.
-	Mechanism string `json:"mechanism" validate:"inclusion=none|apiKey|wcsCreds" default:"none"`
+	Mechanism string `json:"mechanism" validate:"inclusion=none|apiKey|wcsCreds|oidcClientCredentials|bearerToken" default:"none"`
 
-	// A Weaviate API key.
+	// A Weaviate API key. Can also be given as a reference to an
+	// environment variable (`env:WEAVIATE_KEY`) or a file
+	// (`file:/run/secrets/key`).
 	APIKey string `json:"apiKey"`
 
+	// Path to a file containing a Weaviate API key. The file is read again
+	// when it changes or when Weaviate rejects the key, so that rotated
+	// keys are used without restarting the pipeline.
+	APIKeyFile string `json:"apiKeyFile"`
+
 	// Weaviate Cloud Services (WCS) credentials.
 	WCSCredentials WCSCredentials `json:"wcsCreds"`
+
+	// OIDC client credentials.
+	ClientCredentials ClientCredentials `json:"oidcClientCredentials"`
+
+	// Bearer token sent with every request.
+	BearerToken BearerToken `json:"bearerToken"`
 }
 
 func (a Auth) Validate() error {
-	if a.Mechanism == "none" {
+	switch a.Mechanism {
+	case AuthMechanismNone:
 		return nil
-	}
-
-	if a.Mechanism == "apiKey" {
-		if a.APIKey == "" {
+	case AuthMechanismAPIKey:
+		if a.APIKey == "" && a.APIKeyFile == "" {
 			return errors.New("authMechanism set to 'apiKey', but apiKey not specified")
 		}
-
+		if a.APIKey != "" && a.APIKeyFile != "" {
+			return errors.New("only one of apiKey and apiKeyFile can be specified")
+		}
 		return nil
-	}
-
-	if a.Mechanism == "wcsCreds" {
+	case AuthMechanismWCSCreds:
 		return a.WCSCredentials.Validate()
+	case AuthMechanismOIDCClientCredentials:
+		return a.ClientCredentials.Validate()
+	case AuthMechanismBearerToken:
+		return a.BearerToken.Validate()
+	default:
+		return fmt.Errorf("unknown auth mechanism %v", a.Mechanism)
 	}
-
-	return fmt.Errorf("unknown auth mechanism %v", a.Mechanism)
 }
 
 type WCSCredentials struct {
 	// WCS username
 	Username string `json:"username"`
-	// WCS password
+	// WCS password, or an `env:` or `file:` reference to it.
 	Password string `json:"password"`
 }
 
@@ -85,3 +231,44 @@ func (a *WCSCredentials) Validate() error {
 
 	return nil
 }
+
+type ClientCredentials struct {
+	// OIDC client ID. Required if `auth.oidcClientCredentials.tokenUrl`
+	// is set, otherwise the client ID from Weaviate's OIDC configuration
+	// is used.
+	ClientID string `json:"clientId"`
+	// OIDC client secret, or an `env:` or `file:` reference to it.
+	ClientSecret string `json:"clientSecret"`
+	// Scopes requested in addition to the scopes required by Weaviate.
+	Scopes []string `json:"scopes"`
+	// URL of the token endpoint. If empty, the token endpoint is discovered
+	// using Weaviate's OIDC configuration.
+	TokenURL string `json:"tokenUrl"`
+}
+
+func (c *ClientCredentials) Validate() error {
+	if c.ClientSecret == "" {
+		return ErrClientSecretMissing
+	}
+	if c.TokenURL != "" && c.ClientID == "" {
+		return errors.New("client ID required when the token URL is set")
+	}
+
+	return nil
+}
+
+type BearerToken struct {
+	// Static bearer token, or an `env:` or `file:` reference to it.
+	Token string `json:"token"`
+	// Path to a file containing the bearer token. The file is read again
+	// when it changes or when Weaviate rejects the token.
+	File string `json:"file"`
+}
+
+func (b *BearerToken) Validate() error {
+	if (b.Token == "") == (b.File == "") {
+		return ErrBearerTokenMissing
+	}
+
+	return nil
+}
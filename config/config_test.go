@@ -17,6 +17,7 @@ package config_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	weaviate "github.com/conduitio-labs/conduit-connector-weaviate"
@@ -45,11 +46,11 @@ func TestConfig_Auth(t *testing.T) {
 				Config: config.Config{
 					Endpoint: "test-endpoint",
 					Scheme:   "https",
-					Class:    "test-class",
 					Auth: config.Auth{
 						Mechanism: "none",
 					},
 				},
+				Class: "test-class",
 			},
 		},
 		{
@@ -65,12 +66,12 @@ func TestConfig_Auth(t *testing.T) {
 				Config: config.Config{
 					Endpoint: "test-endpoint",
 					Scheme:   "https",
-					Class:    "test-class",
 					Auth: config.Auth{
 						Mechanism: "apiKey",
 						APIKey:    "xyz",
 					},
 				},
+				Class: "test-class",
 			},
 		},
 		{
@@ -87,7 +88,6 @@ func TestConfig_Auth(t *testing.T) {
 				Config: config.Config{
 					Endpoint: "test-endpoint",
 					Scheme:   "https",
-					Class:    "test-class",
 					Auth: config.Auth{
 						Mechanism: "wcsCreds",
 						WCSCredentials: config.WCSCredentials{
@@ -96,6 +96,7 @@ func TestConfig_Auth(t *testing.T) {
 						},
 					},
 				},
+				Class: "test-class",
 			},
 		},
 		{
@@ -120,6 +121,165 @@ func TestConfig_Auth(t *testing.T) {
 			},
 			wantErr: config.ErrUsernamePasswordMissing,
 		},
+		{
+			name: "OIDC client credentials",
+			cfgMap: map[string]string{
+				"endpoint":                            "test-endpoint",
+				"scheme":                              "https",
+				"class":                               "test-class",
+				"auth.mechanism":                      "oidcClientCredentials",
+				"auth.oidcClientCredentials.clientId": "abc",
+				"auth.oidcClientCredentials.clientSecret": "xyz",
+				"auth.oidcClientCredentials.scopes":       "scope1,scope2",
+				"auth.oidcClientCredentials.tokenUrl":     "https://idp.example.com/token",
+			},
+			wantCfg: destination.Config{
+				Config: config.Config{
+					Endpoint: "test-endpoint",
+					Scheme:   "https",
+					Auth: config.Auth{
+						Mechanism: "oidcClientCredentials",
+						ClientCredentials: config.ClientCredentials{
+							ClientID:     "abc",
+							ClientSecret: "xyz",
+							Scopes:       []string{"scope1", "scope2"},
+							TokenURL:     "https://idp.example.com/token",
+						},
+					},
+				},
+				Class: "test-class",
+			},
+		},
+		{
+			name: "OIDC client credentials without secret",
+			cfgMap: map[string]string{
+				"endpoint":                            "test-endpoint",
+				"class":                               "test-class",
+				"auth.mechanism":                      "oidcClientCredentials",
+				"auth.oidcClientCredentials.clientId": "abc",
+			},
+			wantErr: config.ErrClientSecretMissing,
+		},
+		{
+			name: "bearer token",
+			cfgMap: map[string]string{
+				"endpoint":               "test-endpoint",
+				"scheme":                 "https",
+				"class":                  "test-class",
+				"auth.mechanism":         "bearerToken",
+				"auth.bearerToken.token": "xyz",
+			},
+			wantCfg: destination.Config{
+				Config: config.Config{
+					Endpoint: "test-endpoint",
+					Scheme:   "https",
+					Auth: config.Auth{
+						Mechanism:   "bearerToken",
+						BearerToken: config.BearerToken{Token: "xyz"},
+					},
+				},
+				Class: "test-class",
+			},
+		},
+		{
+			name: "bearer token missing",
+			cfgMap: map[string]string{
+				"endpoint":       "test-endpoint",
+				"class":          "test-class",
+				"auth.mechanism": "bearerToken",
+			},
+			wantErr: config.ErrBearerTokenMissing,
+		},
+		{
+			name: "bearer token and bearer token file",
+			cfgMap: map[string]string{
+				"endpoint":               "test-endpoint",
+				"class":                  "test-class",
+				"auth.mechanism":         "bearerToken",
+				"auth.bearerToken.token": "xyz",
+				"auth.bearerToken.file":  "/run/secrets/token",
+			},
+			wantErr: config.ErrBearerTokenMissing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			cfg := destination.Config{}
+			err := sdk.Util.ParseConfig(ctx, tc.cfgMap, &cfg, weaviate.Connector.NewSpecification().DestinationParams)
+			if tc.wantErr == nil {
+				is.NoErr(err)
+			} else {
+				is.True(errors.Is(err, tc.wantErr))
+			}
+
+			err = cfg.Validate(ctx)
+			if tc.wantErr == nil {
+				is.NoErr(err)
+			} else {
+				is.True(errors.Is(err, tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestConfig_TLS(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfgMap  map[string]string
+		wantErr error
+	}{
+		{
+			name: "CA file",
+			cfgMap: map[string]string{
+				"endpoint":   "test-endpoint",
+				"class":      "test-class",
+				"tls.caFile": "/etc/ssl/ca.pem",
+			},
+		},
+		{
+			name: "client certificate",
+			cfgMap: map[string]string{
+				"endpoint":       "test-endpoint",
+				"class":          "test-class",
+				"tls.certFile":   "/etc/ssl/client.pem",
+				"tls.keyFile":    "/etc/ssl/client-key.pem",
+				"tls.serverName": "weaviate.internal",
+			},
+		},
+		{
+			name: "client certificate without key",
+			cfgMap: map[string]string{
+				"endpoint":     "test-endpoint",
+				"class":        "test-class",
+				"tls.certFile": "/etc/ssl/client.pem",
+			},
+			wantErr: config.ErrClientCertificateIncomplete,
+		},
+		{
+			name: "TLS options with scheme http",
+			cfgMap: map[string]string{
+				"endpoint":               "test-endpoint",
+				"class":                  "test-class",
+				"scheme":                 "http",
+				"tls.insecureSkipVerify": "true",
+			},
+			wantErr: config.ErrTLSWithoutHTTPS,
+		},
+		{
+			name: "TLS options with scheme http and secure gRPC",
+			cfgMap: map[string]string{
+				"endpoint":    "test-endpoint",
+				"class":       "test-class",
+				"scheme":      "http",
+				"transport":   "grpc",
+				"grpc.secure": "true",
+				"tls.caFile":  "/etc/ssl/ca.pem",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -144,3 +304,260 @@ func TestConfig_Auth(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_HTTP(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfgMap  map[string]string
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			cfgMap: map[string]string{
+				"endpoint": "test-endpoint",
+				"class":    "test-class",
+			},
+		},
+		{
+			name: "proxy",
+			cfgMap: map[string]string{
+				"endpoint":      "test-endpoint",
+				"class":         "test-class",
+				"http.proxyUrl": "http://proxy.local:3128",
+			},
+		},
+		{
+			name: "proxy without scheme",
+			cfgMap: map[string]string{
+				"endpoint":      "test-endpoint",
+				"class":         "test-class",
+				"http.proxyUrl": "proxy.local:3128",
+			},
+			wantErr: "invalid http.proxyUrl",
+		},
+		{
+			name: "zero timeout",
+			cfgMap: map[string]string{
+				"endpoint":     "test-endpoint",
+				"class":        "test-class",
+				"http.timeout": "0s",
+			},
+			wantErr: "http.timeout must be greater than 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			cfg := destination.Config{}
+			err := sdk.Util.ParseConfig(ctx, tc.cfgMap, &cfg, weaviate.Connector.NewSpecification().DestinationParams)
+			if tc.wantErr == "" {
+				is.NoErr(err)
+				is.NoErr(cfg.Validate(ctx))
+			} else {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr))
+			}
+		})
+	}
+}
+
+func TestConfig_EndpointURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		endpoint string
+		scheme   string
+		want     string
+		wantErr  string
+	}{
+		{name: "host", endpoint: "weaviate.local", scheme: "https", want: "https://weaviate.local"},
+		{name: "host and port", endpoint: "localhost:8080", scheme: "http", want: "http://localhost:8080"},
+		{name: "host and path", endpoint: "gateway.internal/weaviate/", scheme: "https", want: "https://gateway.internal/weaviate"},
+		{name: "URL", endpoint: "http://gateway.internal:8080/weaviate/", scheme: "https", want: "http://gateway.internal:8080/weaviate"},
+		{name: "unsupported scheme", endpoint: "ftp://weaviate.local", scheme: "https", wantErr: "scheme must be http or https"},
+		{name: "missing host", endpoint: "https:///weaviate", scheme: "https", wantErr: "missing host"},
+		{name: "query", endpoint: "https://weaviate.local?x=1", scheme: "https", wantErr: "only scheme, host, port and path are allowed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := config.Config{Endpoint: tc.endpoint, Scheme: tc.scheme}
+			u, err := cfg.EndpointURL()
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(u.String(), tc.want)
+		})
+	}
+}
+
+func TestConfig_GRPCAddress(t *testing.T) {
+	testCases := []struct {
+		name       string
+		grpc       destination.GRPC
+		wantAddr   string
+		wantSecure bool
+		wantErr    string
+	}{
+		{
+			name:     "derived from endpoint",
+			grpc:     destination.GRPC{Port: 50051},
+			wantAddr: "gateway.internal:50051",
+		},
+		{
+			name:       "host and port",
+			grpc:       destination.GRPC{Host: "grpc.internal", Port: 443, Secure: true},
+			wantAddr:   "grpc.internal:443",
+			wantSecure: true,
+		},
+		{
+			name:     "endpoint",
+			grpc:     destination.GRPC{Endpoint: "grpc.internal:8443", Host: "ignored", Port: 50051},
+			wantAddr: "grpc.internal:8443",
+		},
+		{
+			name:       "endpoint URL",
+			grpc:       destination.GRPC{Endpoint: "https://grpc.internal", Port: 50051},
+			wantAddr:   "grpc.internal:50051",
+			wantSecure: true,
+		},
+		{
+			name:    "endpoint without port",
+			grpc:    destination.GRPC{Endpoint: "grpc.internal", Port: 50051},
+			wantErr: "missing port",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cfg := destination.Config{
+				Config: config.Config{Endpoint: "https://gateway.internal/weaviate"},
+				GRPC:   tc.grpc,
+			}
+			addr, secure, err := cfg.GRPCAddress()
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(addr, tc.wantAddr)
+			is.Equal(secure, tc.wantSecure)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfgMap  map[string]string
+		wantErr string
+	}{
+		{
+			name: "throttle max rate below min rate",
+			cfgMap: map[string]string{
+				"throttle.enabled":     "true",
+				"throttle.minRate":     "10",
+				"throttle.maxRate":     "5",
+				"throttle.increase":    "1",
+				"throttle.decrease":    "0.5",
+				"throttle.initialRate": "7",
+			},
+			wantErr: "throttle.maxRate",
+		},
+		{
+			name: "chunk overlap not less than chunk size",
+			cfgMap: map[string]string{
+				"chunking.enabled":  "true",
+				"chunking.property": "body",
+				"chunking.size":     "100",
+				"chunking.overlap":  "100",
+			},
+			wantErr: "chunking.overlap must be at least 0 and less than chunking.size",
+		},
+		{
+			name: "hashing embeddings without dimensions",
+			cfgMap: map[string]string{
+				"embedding.provider":   "hashing",
+				"embedding.properties": "body",
+			},
+			wantErr: "embedding.dimensions is required by the hashing provider",
+		},
+		{
+			name: "embedding cache without path",
+			cfgMap: map[string]string{
+				"embedding.provider":      "openai",
+				"embedding.properties":    "body",
+				"embedding.cache.enabled": "true",
+			},
+			wantErr: "embedding.cache.path is required if embedding.cache.enabled is true",
+		},
+		{
+			name: "content hash with chunking",
+			cfgMap: map[string]string{
+				"contentHash.enabled": "true",
+				"chunking.enabled":    "true",
+				"chunking.property":   "body",
+			},
+			wantErr: "contentHash.enabled can't be combined with chunking.enabled",
+		},
+		{
+			name: "soft delete with chunking",
+			cfgMap: map[string]string{
+				"softDelete.enabled": "true",
+				"chunking.enabled":   "true",
+				"chunking.property":  "body",
+			},
+			wantErr: "softDelete.enabled can't be combined with chunking.enabled",
+		},
+		{
+			name: "IDs from positions with updates",
+			cfgMap: map[string]string{
+				"operations.id": "keyAndPosition",
+			},
+			wantErr: "operations.update must be insert or ignore if operations.id is keyAndPosition",
+		},
+		{
+			name: "IDs from positions with deletes",
+			cfgMap: map[string]string{
+				"operations.id":     "keyAndPosition",
+				"operations.update": "insert",
+			},
+			wantErr: "operations.delete must be insert or ignore if operations.id is keyAndPosition",
+		},
+		{
+			name: "IDs from positions with chunking",
+			cfgMap: map[string]string{
+				"operations.id":     "keyAndPosition",
+				"operations.update": "insert",
+				"operations.delete": "ignore",
+				"chunking.enabled":  "true",
+				"chunking.property": "body",
+			},
+			wantErr: "operations.id keyAndPosition can't be combined with chunking.enabled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+			tc.cfgMap["endpoint"] = "test-endpoint"
+			tc.cfgMap["class"] = "test-class"
+
+			cfg := destination.Config{}
+			err := sdk.Util.ParseConfig(ctx, tc.cfgMap, &cfg, weaviate.Connector.NewSpecification().DestinationParams)
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.wantErr)) // error is missing the expected message
+		})
+	}
+}
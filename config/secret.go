@@ -0,0 +1,87 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// SecretPrefixEnv is the prefix of secret references naming an
+	// environment variable, e.g. `env:WEAVIATE_KEY`.
+	SecretPrefixEnv = "env:"
+	// SecretPrefixFile is the prefix of secret references naming a file,
+	// e.g. `file:/run/secrets/key`.
+	SecretPrefixFile = "file:"
+)
+
+// ResolveSecret returns the secret referenced by value. References start with
+// `env:` followed by the name of an environment variable, or with `file:`
+// followed by the path of a file, whose content is used without leading and
+// trailing whitespace. Other values are returned as they are.
+func ResolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, SecretPrefixEnv):
+		name := strings.TrimPrefix(value, SecretPrefixEnv)
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %v is not set", name)
+		}
+		if secret == "" {
+			return "", fmt.Errorf("environment variable %v is empty", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, SecretPrefixFile):
+		path := strings.TrimPrefix(value, SecretPrefixFile)
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading secret file: %w", err)
+		}
+		secret := strings.TrimSpace(string(bytes))
+		if secret == "" {
+			return "", errors.New("secret file is empty")
+		}
+		return secret, nil
+	default:
+		return value, nil
+	}
+}
+
+// ResolveSecrets resolves the secret references in fields, which are keyed by
+// the name of their parameter.
+func ResolveSecrets(fields map[string]*string) error {
+	for param, value := range fields {
+		secret, err := ResolveSecret(*value)
+		if err != nil {
+			return fmt.Errorf("error resolving %v: %w", param, err)
+		}
+		*value = secret
+	}
+
+	return nil
+}
+
+// ResolveSecrets resolves the secret references in the credentials.
+func (c *Config) ResolveSecrets() error {
+	return ResolveSecrets(map[string]*string{
+		"auth.apiKey":                             &c.Auth.APIKey,
+		"auth.wcsCreds.password":                  &c.Auth.WCSCredentials.Password,
+		"auth.oidcClientCredentials.clientSecret": &c.Auth.ClientCredentials.ClientSecret,
+		"auth.bearerToken.token":                  &c.Auth.BearerToken.Token,
+	})
+}
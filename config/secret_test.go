@@ -0,0 +1,67 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/config"
+	"github.com/matryer/is"
+)
+
+func TestResolveSecret(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	is.New(t).NoErr(os.WriteFile(keyFile, []byte("file-secret\n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty")
+	is.New(t).NoErr(os.WriteFile(emptyFile, nil, 0o600))
+
+	t.Setenv("WEAVIATE_TEST_KEY", "env-secret")
+	t.Setenv("WEAVIATE_TEST_EMPTY", "")
+
+	testCases := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "plaintext", value: "plain-secret", want: "plain-secret"},
+		{name: "empty", value: "", want: ""},
+		{name: "environment variable", value: "env:WEAVIATE_TEST_KEY", want: "env-secret"},
+		{name: "missing environment variable", value: "env:WEAVIATE_TEST_MISSING", wantErr: "environment variable WEAVIATE_TEST_MISSING is not set"},
+		{name: "empty environment variable", value: "env:WEAVIATE_TEST_EMPTY", wantErr: "environment variable WEAVIATE_TEST_EMPTY is empty"},
+		{name: "file", value: "file:" + keyFile, want: "file-secret"},
+		{name: "missing file", value: "file:" + filepath.Join(dir, "missing"), wantErr: "error reading secret file"},
+		{name: "empty file", value: "file:" + emptyFile, wantErr: "secret file is empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			got, err := config.ResolveSecret(tc.value)
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	_ "embed"
 
 	"github.com/conduitio-labs/conduit-connector-weaviate/destination"
+	"github.com/conduitio-labs/conduit-connector-weaviate/source"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 )
 
@@ -33,6 +34,6 @@ var version = "(devel)"
 // Connector combines all constructors for each plugin in one struct.
 var Connector = sdk.Connector{
 	NewSpecification: sdk.YAMLSpecification(specs, version),
-	NewSource:        nil,
+	NewSource:        source.New,
 	NewDestination:   destination.New,
 }
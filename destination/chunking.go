@@ -0,0 +1,192 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/google/uuid"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+const (
+	ChunkUnitCharacters = "characters"
+	ChunkUnitTokens     = "tokens"
+	ChunkUnitSentences  = "sentences"
+)
+
+// writeChunks splits obj into chunks and writes them. Chunks left over from
+// a previous version of the object, which had more chunks, are deleted.
+func (d *Destination) writeChunks(ctx context.Context, obj *weaviate.Object) error {
+	chunks, err := d.chunkObjects(obj)
+	if err != nil {
+		return fmt.Errorf("error splitting object into chunks: %w", err)
+	}
+
+	err = d.embed(ctx, chunks)
+	if err != nil {
+		return err
+	}
+	if len(chunks) > 0 {
+		err = d.client.Upsert(ctx, chunks)
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.deleteChunks(ctx, obj, len(chunks))
+}
+
+// deleteChunks deletes the chunks of obj with an index of at least from. The
+// index is compared as an integer or a number, depending on the type of the
+// index property, which is a number if it was created by auto-schema. If the
+// index property doesn't exist, no chunks were written yet.
+func (d *Destination) deleteChunks(ctx context.Context, obj *weaviate.Object, from int) error {
+	cfg := d.config.Chunking
+	indexType, err := d.propertyType(ctx, obj.Class, cfg.IndexProperty)
+	if err != nil {
+		return fmt.Errorf("error getting type of %v: %w", cfg.IndexProperty, err)
+	}
+	if indexType == "" {
+		return nil
+	}
+
+	where := filters.Where().
+		WithPath([]string{cfg.ParentProperty}).
+		WithOperator(filters.Equal).
+		WithValueText(obj.ID)
+	if from > 0 {
+		index := filters.Where().
+			WithPath([]string{cfg.IndexProperty}).
+			WithOperator(filters.GreaterThanEqual)
+		if indexType == "int" {
+			index = index.WithValueInt(int64(from))
+		} else {
+			index = index.WithValueNumber(float64(from))
+		}
+		where = filters.Where().
+			WithOperator(filters.And).
+			WithOperands([]*filters.WhereBuilder{where, index})
+	}
+
+	err = d.client.DeleteWhere(ctx, obj.Class, obj.Tenant, where)
+	if err != nil {
+		return fmt.Errorf("error deleting chunks: %w", err)
+	}
+
+	return nil
+}
+
+// chunkObjects returns an object per chunk of the chunked property of obj.
+// The chunks have the properties of obj, with the chunked property replaced
+// by the chunk, and the ID of obj and the index of the chunk added. Their IDs
+// are derived from the ID of obj and the index, so that writing a new
+// version of obj replaces its chunks. Vectors of obj aren't copied, as they
+// were computed for the whole text, the chunks are embedded instead if
+// embedding is configured.
+func (d *Destination) chunkObjects(obj *weaviate.Object) ([]*weaviate.Object, error) {
+	cfg := d.config.Chunking
+
+	var text string
+	switch v := obj.Properties[cfg.Property].(type) {
+	case string:
+		text = v
+	case nil:
+	default:
+		return nil, fmt.Errorf("property %v is a %T, not text", cfg.Property, v)
+	}
+
+	parent, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object ID %q: %w", obj.ID, err)
+	}
+
+	chunks := splitText(text, cfg.Unit, cfg.Size, cfg.Overlap)
+	objects := make([]*weaviate.Object, len(chunks))
+	for i, chunk := range chunks {
+		properties := maps.Clone(obj.Properties)
+		properties[cfg.Property] = chunk
+		properties[cfg.ParentProperty] = obj.ID
+		properties[cfg.IndexProperty] = i
+
+		objects[i] = &weaviate.Object{
+			ID:         uuid.NewSHA1(parent, []byte(strconv.Itoa(i))).String(),
+			Class:      obj.Class,
+			Properties: properties,
+			Tenant:     obj.Tenant,
+		}
+	}
+
+	return objects, nil
+}
+
+// splitText splits text into chunks of size units, where each chunk starts
+// with the last overlap units of the previous chunk. Tokens and sentences are
+// joined by a single space.
+func splitText(text, unit string, size, overlap int) []string {
+	var (
+		units []string
+		sep   string
+	)
+	switch unit {
+	case ChunkUnitTokens:
+		units, sep = strings.Fields(text), " "
+	case ChunkUnitSentences:
+		units, sep = splitSentences(text), " "
+	default:
+		units = strings.Split(text, "")
+	}
+
+	var chunks []string
+	for start := 0; start < len(units); start += size - overlap {
+		end := min(start+size, len(units))
+		chunks = append(chunks, strings.Join(units[start:end], sep))
+		if end == len(units) {
+			break
+		}
+	}
+
+	return chunks
+}
+
+// splitSentences splits text after every `.`, `!` or `?` followed by
+// whitespace. The sentences are trimmed, empty sentences are left out.
+func splitSentences(text string) []string {
+	var (
+		sentences []string
+		start     int
+	)
+	runes := []rune(text)
+	for i, r := range runes {
+		end := i == len(runes)-1
+		if !end && !(strings.ContainsRune(".!?", r) && unicode.IsSpace(runes[i+1])) {
+			continue
+		}
+
+		sentence := strings.TrimSpace(string(runes[start : i+1]))
+		if sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = i + 1
+	}
+
+	return sentences
+}
@@ -18,30 +18,387 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/conduitio-labs/conduit-connector-weaviate/config"
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 )
 
 type Config struct {
 	sdk.DefaultDestinationMiddleware
 	config.Config
-	// TODO: better naming for this value __sL__
-	// Vectorizers which can be configured client side
-	// mostly require an API key only.
-	// However, OpenAI can also be configured with an organization
-	// via the X-OpenAI-Organization header.
 
+	// The class name as defined in the schema.
+	// A record will be saved under this class unless
+	// it has the `weaviate.class` metadata field.
+	Class string `json:"class" validate:"required"`
+
+	// Transport used to write and delete objects. With `grpc`, the
+	// connector falls back to HTTP if the gRPC API is unavailable. Updates
+	// create missing objects with both transports. Inserts replace existing
+	// objects with `grpc`, but fail with `http` unless `workers` is greater
+	// than 1 or soft deletes are enabled.
+	Transport string `json:"transport" default:"http" validate:"inclusion=http|grpc"`
+
+	GRPC GRPC `json:"grpc"`
+
+	// Single header configuring a module. Kept for backwards
+	// compatibility, `headers` and `providers` can be used to configure
+	// multiple modules.
 	ModuleHeader ModuleHeader `json:"moduleHeader"`
+	// Headers sent with every request, e.g. `headers.X-OpenAI-Organization`.
+	Headers map[string]string `json:"headers"`
+	// Credentials of the vectorizer and generative module providers, sent
+	// to Weaviate as headers. API keys can be `env:` or `file:` references.
+	Providers Providers `json:"providers"`
+	// Secrets that records can reference by name in `weaviate.header.*`
+	// metadata fields, e.g. `secrets.customer-a`. A record with the metadata
+	// field `weaviate.header.X-OpenAI-Api-Key: customer-a` is written with
+	// the secret `customer-a` as its OpenAI API key. Values can be `env:`
+	// or `file:` references.
+	Secrets map[string]string `json:"secrets"`
 	// Whether a UUID for records should be automatically generated.
 	// The generated UUIDs are MD5 sums of record keys.
 	GenerateUUID bool `json:"generateUUID"`
+
+	// Number of goroutines writing records concurrently. Records are
+	// partitioned by object ID, so that operations on the same object
+	// are always written in order.
+	Workers int `json:"workers" default:"1" validate:"greater-than=0"`
+
+	Throttle ThrottleConfig `json:"throttle"`
+
+	Preflight PreflightConfig `json:"preflight"`
+
+	Chunking ChunkingConfig `json:"chunking"`
+
+	Embedding EmbeddingConfig `json:"embedding"`
+
+	ContentHash ContentHashConfig `json:"contentHash"`
+
+	// Field the version of records is read from, which is the name of a
+	// payload field, or of a metadata field prefixed with `metadata.`, e.g.
+	// `metadata.opencdc.readAt`. Versions are integers or RFC 3339
+	// timestamps. If set, the version is stored on the objects, and records
+	// older than the stored version are ignored. Deleted objects lose their
+	// version, so an older record arriving after a delete recreates the
+	// object, unless soft deletes are enabled.
+	VersionField string `json:"versionField"`
+	// Name of the text property the version is stored in, as a decimal
+	// integer. Timestamps are stored in Unix nanoseconds.
+	VersionProperty string `json:"versionProperty" default:"_version"`
+
+	SoftDelete SoftDeleteConfig `json:"softDelete"`
+
+	Operations OperationsConfig `json:"operations"`
+}
+
+type OperationsConfig struct {
+	// How records creating objects are written: `insert`, `update` or
+	// `ignore`.
+	Create string `json:"create" default:"insert" validate:"inclusion=insert|update|ignore"`
+	// How records updating objects are written: `insert`, `update` or
+	// `ignore`.
+	Update string `json:"update" default:"update" validate:"inclusion=insert|update|ignore"`
+	// How records deleting objects are written: `delete`, `insert` (with the
+	// payload before the delete) or `ignore`.
+	Delete string `json:"delete" default:"delete" validate:"inclusion=delete|insert|ignore"`
+	// How snapshot records are written: `insert`, `update` or `ignore`.
+	Snapshot string `json:"snapshot" default:"insert" validate:"inclusion=insert|update|ignore"`
+	// How the IDs of objects are derived: `key`, from the record key (see
+	// `generateUUID`), or `keyAndPosition`, from the record key and
+	// position, so that every record is written as its own object, which
+	// requires all operations to be `insert` or `ignore`.
+	ID string `json:"id" default:"key" validate:"inclusion=key|keyAndPosition"`
+	// Name of the text property set to the operation of the record, e.g.
+	// `update`. If empty, the operation isn't stored.
+	Property string `json:"property"`
+}
+
+// Validate checks that objects written with IDs derived from the record
+// positions, which are unique per record, are only inserted, as there are
+// no objects with the same ID to update or delete.
+func (o OperationsConfig) Validate() error {
+	if o.ID != IDKeyAndPosition {
+		return nil
+	}
+
+	ops := []struct {
+		name  string
+		value string
+	}{
+		{"operations.create", o.Create},
+		{"operations.update", o.Update},
+		{"operations.snapshot", o.Snapshot},
+	}
+	for _, op := range ops {
+		if op.value != OperationInsert && op.value != OperationIgnore {
+			return fmt.Errorf("%v must be insert or ignore if operations.id is keyAndPosition", op.name)
+		}
+	}
+	if o.Delete == OperationDelete {
+		return errors.New("operations.delete must be insert or ignore if operations.id is keyAndPosition")
+	}
+
+	return nil
+}
+
+type SoftDeleteConfig struct {
+	// Whether deletes mark objects as deleted, instead of deleting them.
+	// Created objects replace soft deleted objects.
+	Enabled bool `json:"enabled" default:"false"`
+	// Name of the boolean property which is true for deleted objects, and
+	// false for other objects.
+	Property string `json:"property" default:"deleted"`
+	// Name of the date property set to the time an object was deleted, which
+	// is the read time of the delete record. If empty, the time isn't
+	// stored.
+	TimestampProperty string `json:"timestampProperty" default:"deletedAt"`
+}
+
+type ContentHashConfig struct {
+	// Whether a hash of the properties and vectors of written objects is
+	// stored in the object, and objects are only written if their hash
+	// changed. The version and operation properties aren't hashed, a newer
+	// version of an unchanged object is written on its own.
+	Enabled bool `json:"enabled" default:"false"`
+	// Name of the text property the hash is stored in.
+	Property string `json:"property" default:"_contentHash"`
+}
+
+type EmbeddingConfig struct {
+	// Provider computing vectors before objects are written: `none`,
+	// `openai` (an OpenAI compatible API) or `hashing` (a hash of the words
+	// of the text, which is deterministic but doesn't capture its meaning,
+	// for testing).
+	Provider string `json:"provider" default:"none" validate:"inclusion=none|openai|hashing"`
+	// Text properties embedded, joined by newlines. With chunking, the
+	// properties of the chunks are embedded.
+	Properties []string `json:"properties"`
+	// Name of the named vector the embedding is written to. If empty, the
+	// embedding is written as the object's vector, unless the record has a
+	// `weaviate.vector` metadata field.
+	Vector string `json:"vector"`
+	// Number of dimensions of the vectors. Required by `hashing`, optional
+	// for `openai` models supporting shortened vectors.
+	Dimensions int `json:"dimensions"`
+	// Maximum number of texts embedded with a single request. The texts of
+	// all records written at once are embedded together.
+	BatchSize int `json:"batchSize" default:"100" validate:"greater-than=0"`
+
+	OpenAI OpenAIEmbeddingConfig `json:"openai"`
+	Cache  EmbeddingCacheConfig  `json:"cache"`
+}
+
+type OpenAIEmbeddingConfig struct {
+	// URL of the OpenAI compatible API, the embeddings are requested from
+	// `<baseUrl>/embeddings`.
+	BaseURL string `json:"baseUrl" default:"https://api.openai.com/v1"`
+	// API key sent as bearer token, or an `env:` or `file:` reference to it.
+	APIKey string `json:"apiKey"`
+	// Name of the embedding model.
+	Model string `json:"model" default:"text-embedding-3-small"`
+	// Timeout of an embeddings request.
+	Timeout time.Duration `json:"timeout" default:"60s"`
+}
+
+type EmbeddingCacheConfig struct {
+	// Whether vectors are cached by the hash of the embedded text, so that
+	// unchanged texts aren't embedded again.
+	Enabled bool `json:"enabled" default:"false"`
+	// Path of the BoltDB file the vectors are cached in, which is created if
+	// it doesn't exist. Required if the cache is enabled. The file is locked
+	// while the destination is open, so pipelines running at the same time
+	// need their own files.
+	Path string `json:"path"`
+	// Maximum number of cached vectors. The vectors cached first are evicted
+	// first.
+	MaxEntries int `json:"maxEntries" default:"100000" validate:"greater-than=0"`
+}
+
+func (e EmbeddingConfig) Validate() error {
+	if e.Provider == ProviderNone {
+		return nil
+	}
+
+	switch {
+	case len(e.Properties) == 0:
+		return errors.New("embedding.properties is required if embedding.provider is set")
+	case e.Dimensions < 0:
+		return errors.New("embedding.dimensions must not be negative")
+	case e.Provider == ProviderHashing && e.Dimensions == 0:
+		return errors.New("embedding.dimensions is required by the hashing provider")
+	case e.Provider == ProviderOpenAI && (e.OpenAI.BaseURL == "" || e.OpenAI.Model == ""):
+		return errors.New("embedding.openai.baseUrl and embedding.openai.model are required by the openai provider")
+	case e.Cache.Enabled && e.Cache.Path == "":
+		return errors.New("embedding.cache.path is required if embedding.cache.enabled is true")
+	}
+
+	return nil
+}
+
+type ChunkingConfig struct {
+	// Whether the text property `chunking.property` should be split into
+	// chunks, each written as a separate object, instead of writing the
+	// record as a single object. The chunks of a record are always replaced
+	// or deleted together, so chunking can't be combined with `versionField`,
+	// `softDelete.enabled`, `contentHash.enabled` or `operations.id`
+	// `keyAndPosition`.
+	Enabled bool `json:"enabled"`
+	// Name of the text property split into chunks.
+	Property string `json:"property"`
+	// Unit the chunk size and overlap are measured in: `characters`,
+	// `tokens` (words separated by whitespace) or `sentences`.
+	Unit string `json:"unit" default:"characters" validate:"inclusion=characters|tokens|sentences"`
+	// Number of units in a chunk.
+	Size int `json:"size" default:"1000" validate:"greater-than=0"`
+	// Number of units at the end of a chunk that are repeated at the start
+	// of the next chunk.
+	Overlap int `json:"overlap" default:"0"`
+	// Name of the property the ID of the object a chunk was created from is
+	// written to.
+	ParentProperty string `json:"parentProperty" default:"parentId"`
+	// Name of the property the position of a chunk within the object it was
+	// created from is written to, starting at 0.
+	IndexProperty string `json:"indexProperty" default:"chunkIndex"`
+}
+
+func (c ChunkingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	switch {
+	case c.Property == "":
+		return errors.New("chunking.property is required if chunking.enabled is true")
+	case c.Overlap < 0 || c.Overlap >= c.Size:
+		return errors.New("chunking.overlap must be at least 0 and less than chunking.size")
+	case c.ParentProperty == "" || c.IndexProperty == "":
+		return errors.New("chunking.parentProperty and chunking.indexProperty must not be empty")
+	case c.ParentProperty == c.Property || c.IndexProperty == c.Property || c.ParentProperty == c.IndexProperty:
+		return errors.New("chunking.property, chunking.parentProperty and chunking.indexProperty must be different")
+	}
+
+	return nil
+}
+
+type GRPC struct {
+	// Address of the Weaviate gRPC API as `host:port`, or as a URL with
+	// scheme `http` or `https`, where `https` means the API is served over
+	// TLS. Takes precedence over `grpc.host` and `grpc.port`.
+	Endpoint string `json:"endpoint"`
+	// Host of the Weaviate gRPC API. Defaults to the host in `endpoint`.
+	Host string `json:"host"`
+	// Port of the Weaviate gRPC API.
+	Port int `json:"port" default:"50051"`
+	// Whether the gRPC API is served over TLS.
+	Secure bool `json:"secure"`
+}
+
+// GRPCAddress returns the address of the Weaviate gRPC API and whether it's
+// served over TLS.
+func (c *Config) GRPCAddress() (string, bool, error) {
+	secure := c.GRPC.Secure
+	port := strconv.Itoa(c.GRPC.Port)
+
+	if endpoint := c.GRPC.Endpoint; endpoint != "" {
+		if strings.Contains(endpoint, "://") {
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid grpc.endpoint: %w", err)
+			}
+			if u.Scheme != "http" && u.Scheme != "https" || u.Hostname() == "" {
+				return "", false, fmt.Errorf("invalid grpc.endpoint %q: expected scheme http or https and a host", endpoint)
+			}
+			secure = secure || u.Scheme == "https"
+			if u.Port() != "" {
+				port = u.Port()
+			}
+			return net.JoinHostPort(u.Hostname(), port), secure, nil
+		}
+
+		host, p, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid grpc.endpoint %q: %w", endpoint, err)
+		}
+		return net.JoinHostPort(host, p), secure, nil
+	}
+
+	host := c.GRPC.Host
+	if host == "" {
+		u, err := c.EndpointURL()
+		if err != nil {
+			return "", false, err
+		}
+		host = u.Hostname()
+	}
+
+	return net.JoinHostPort(host, port), secure, nil
+}
+
+type PreflightConfig struct {
+	// Whether the Weaviate instance and the configuration should be checked
+	// when the destination is opened (connectivity, credentials, version,
+	// class and modules). A missing class is only logged, since auto-schema
+	// can create it on the first write.
+	Enabled bool `json:"enabled" default:"true"`
+	// Whether the class should be created by the checks if it doesn't exist,
+	// instead of leaving it to auto-schema.
+	CreateClass bool `json:"createClass"`
+}
+
+type ThrottleConfig struct {
+	// Whether the write rate should be adapted when Weaviate, or a vectorizer
+	// module used by Weaviate, responds that the rate limit has been reached.
+	Enabled bool `json:"enabled"`
+	// Number of records per second written when the destination starts.
+	InitialRate float64 `json:"initialRate" default:"100"`
+	// Lowest number of records per second the rate can be decreased to.
+	MinRate float64 `json:"minRate" default:"1"`
+	// Highest number of records per second the rate can be increased to.
+	MaxRate float64 `json:"maxRate" default:"1000"`
+	// Number of records per second the rate is increased by after
+	// every successful write.
+	Increase float64 `json:"increase" default:"1"`
+	// Factor the rate is multiplied with after a rate-limited write.
+	Decrease float64 `json:"decrease" default:"0.5"`
+	// Maximum number of times a rate-limited record is retried.
+	MaxRetries int `json:"maxRetries" default:"10"`
+}
+
+func (t ThrottleConfig) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+
+	switch {
+	case t.MinRate <= 0:
+		return errors.New("throttle.minRate must be greater than 0")
+	case t.MaxRate < t.MinRate:
+		return errors.New("throttle.maxRate must not be lower than throttle.minRate")
+	case t.InitialRate < t.MinRate || t.InitialRate > t.MaxRate:
+		return errors.New("throttle.initialRate must be between throttle.minRate and throttle.maxRate")
+	case t.Increase < 0:
+		return errors.New("throttle.increase must not be negative")
+	case t.Decrease <= 0 || t.Decrease >= 1:
+		return errors.New("throttle.decrease must be between 0 and 1 (exclusive)")
+	case t.MaxRetries < 0:
+		return errors.New("throttle.maxRetries must not be negative")
+	}
+
+	return nil
 }
 
 type ModuleHeader struct {
 	// Name of the header configuring a module (e.g. `X-OpenAI-Api-Key`)
 	Name string `json:"name"`
-	// Value for header given in `moduleHeader.name`.
+	// Value for header given in `moduleHeader.name`, or an `env:` or
+	// `file:` reference to it.
 	Value string `json:"value"`
 }
 
@@ -60,10 +417,111 @@ func (c *Config) Validate(ctx context.Context) error {
 		return errors.New("invalid module configuration")
 	}
 
+	_, err = c.ModuleHeaders()
+	if err != nil {
+		return fmt.Errorf("invalid headers: %w", err)
+	}
+
+	for name, value := range c.Secrets {
+		if value == "" {
+			return fmt.Errorf("secret %v is empty", name)
+		}
+	}
+
 	err = c.Config.Validate()
 	if err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if c.Transport == weaviate.TransportGRPC {
+		_, _, err = c.GRPCAddress()
+		if err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	err = c.validateTLS()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	err = c.Throttle.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid throttle configuration: %w", err)
+	}
+
+	err = c.Chunking.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid chunking configuration: %w", err)
+	}
+
+	err = c.Embedding.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid embedding configuration: %w", err)
+	}
+
+	if c.VersionField != "" {
+		switch {
+		case c.VersionProperty == "":
+			return errors.New("invalid configuration: versionProperty is required if versionField is set")
+		case c.Chunking.Enabled:
+			return errors.New("invalid configuration: versionField can't be combined with chunking.enabled")
+		}
+	}
+
+	if c.SoftDelete.Enabled {
+		switch {
+		case c.SoftDelete.Property == "":
+			return errors.New("invalid configuration: softDelete.property is required if softDelete.enabled is true")
+		case c.Chunking.Enabled:
+			return errors.New("invalid configuration: softDelete.enabled can't be combined with chunking.enabled")
+		}
+	}
+
+	if c.ContentHash.Enabled {
+		switch {
+		case c.ContentHash.Property == "":
+			return errors.New("invalid configuration: contentHash.property is required if contentHash.enabled is true")
+		case c.Chunking.Enabled:
+			return errors.New("invalid configuration: contentHash.enabled can't be combined with chunking.enabled")
+		}
+	}
+
+	err = c.Operations.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid operations configuration: %w", err)
+	}
+	if c.Operations.ID == IDKeyAndPosition && c.Chunking.Enabled {
+		return errors.New("invalid configuration: operations.id keyAndPosition can't be combined with chunking.enabled")
+	}
+
 	return nil
 }
+
+// validateTLS checks that the TLS options are used, either by the endpoint or
+// by a secure gRPC API.
+func (c *Config) validateTLS() error {
+	if !c.TLS.IsSet() {
+		return nil
+	}
+
+	u, err := c.EndpointURL()
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "https" {
+		return nil
+	}
+
+	if c.Transport == weaviate.TransportGRPC {
+		_, secure, err := c.GRPCAddress()
+		if err != nil {
+			return err
+		}
+		if secure {
+			return nil
+		}
+	}
+
+	return config.ErrTLSWithoutHTTPS
+}
@@ -0,0 +1,76 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// unchanged stores the content hash of obj in the configured property, and
+// returns true if the object already has that hash, in which case it doesn't
+// need to be written. The version and the operation of the record aren't
+// part of the content, so that a record only differing in those is
+// unchanged.
+func (d *Destination) unchanged(ctx context.Context, obj *weaviate.Object) bool {
+	if !d.config.ContentHash.Enabled {
+		return false
+	}
+
+	// a hash read from Weaviate, e.g. by the source, is replaced
+	delete(obj.Properties, d.config.ContentHash.Property)
+	content := *obj
+	content.Properties = maps.Clone(obj.Properties)
+	if d.config.VersionField != "" {
+		delete(content.Properties, d.config.VersionProperty)
+	}
+	if d.config.Operations.Property != "" {
+		delete(content.Properties, d.config.Operations.Property)
+	}
+	hash := contentHash(&content)
+	obj.Properties[d.config.ContentHash.Property] = hash
+
+	stored, ok := storedValue(ctx, newObjectKey(obj), d.config.ContentHash.Property)
+	if !ok || stored != hash {
+		return false
+	}
+
+	sdk.Logger(ctx).Debug().
+		Str("class", obj.Class).
+		Str("id", obj.ID).
+		Msg("object unchanged, skipping write")
+	return true
+}
+
+// contentHash returns the hex encoded SHA-256 hash of the properties and
+// vectors of obj.
+func contentHash(obj *weaviate.Object) string {
+	// maps are encoded with sorted keys, which makes the encoding
+	// deterministic
+	data, _ := json.Marshal(struct {
+		Properties map[string]any       `json:"properties"`
+		Vector     []float32            `json:"vector"`
+		Vectors    map[string][]float32 `json:"vectors"`
+	}{obj.Properties, obj.Vector, obj.Vectors})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -21,34 +21,63 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/conduitio/conduit-commons/opencdc"
 
-	"github.com/conduitio-labs/conduit-connector-weaviate/destination/weaviate"
+	"github.com/conduitio-labs/conduit-connector-weaviate/destination/embedding"
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/google/uuid"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 )
 
+// The metadata fields used by the destination, see the weaviate package.
 var (
-	MetadataClass  = "weaviate.class"
-	MetadataVector = "weaviate.vector"
+	MetadataClass              = weaviate.MetadataClass
+	MetadataVector             = weaviate.MetadataVector
+	MetadataVectorsPrefix      = weaviate.MetadataVectorsPrefix
+	MetadataTenant             = weaviate.MetadataTenant
+	MetadataCreationTimeUnix   = weaviate.MetadataCreationTimeUnix
+	MetadataLastUpdateTimeUnix = weaviate.MetadataLastUpdateTimeUnix
+	MetadataHeaderPrefix       = weaviate.MetadataHeaderPrefix
 )
 
 type weaviateClient interface {
 	Open(weaviate.Config) error
 
+	Live(context.Context) (bool, error)
+	Ready(context.Context) (bool, error)
+	Meta(context.Context) (*weaviate.Meta, error)
+	ClassExists(ctx context.Context, class string) (bool, error)
+	Class(ctx context.Context, class string) (*weaviate.Class, error)
+	CreateClass(ctx context.Context, class string) error
+
 	Insert(context.Context, *weaviate.Object) error
 	Update(context.Context, *weaviate.Object) error
 	Delete(context.Context, *weaviate.Object) error
+	Upsert(context.Context, []*weaviate.Object) error
+	Merge(context.Context, *weaviate.Object) error
+	DeleteWhere(ctx context.Context, class, tenant string, where *filters.WhereBuilder) error
+	TextProperties(ctx context.Context, class, tenant string, properties, ids []string) (map[string]map[string]string, error)
+
+	Close() error
 }
 
 type Destination struct {
 	sdk.UnimplementedDestination
 
-	config Config
-	client weaviateClient
+	config    Config
+	client    weaviateClient
+	throttler *throttler
+	embedder  embedding.Embedder
+	cache     *embedding.Cache
+	// propertyTypes contains the data types of properties by class and
+	// property name, see propertyType.
+	propertyTypes sync.Map
 }
 
 func New() sdk.Destination {
@@ -65,25 +94,81 @@ func (d *Destination) Config() sdk.DestinationConfig {
 	return &d.config
 }
 
-func (d *Destination) Open(context.Context) error {
-	err := d.client.Open(d.weaviateConfig())
+func (d *Destination) Open(ctx context.Context) error {
+	err := d.resolveSecrets()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := d.weaviateConfig()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	err = d.client.Open(cfg)
 	if err != nil {
 		return fmt.Errorf("error creating client: %w}", err)
 	}
 
+	if d.config.Preflight.Enabled {
+		err = d.preflight(ctx)
+		if err != nil {
+			return fmt.Errorf("preflight checks failed: %w", err)
+		}
+	}
+
+	if d.config.Throttle.Enabled {
+		d.throttler = newThrottler(d.config.Throttle)
+	}
+
+	d.embedder, err = d.newEmbedder()
+	if err != nil {
+		return fmt.Errorf("error creating embedder: %w", err)
+	}
+
 	return nil
 }
 
 func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int, error) {
+	if len(d.storedProperties()) > 0 {
+		var err error
+		ctx, err = d.fetchStored(ctx, records)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if d.embedder != nil {
+		ctx = d.embedRecords(ctx, records)
+	}
+	if d.cache != nil {
+		hits, misses := d.cache.Stats()
+		defer d.logCacheStats(ctx, hits, misses)
+	}
+
+	var n int
+	var err error
+	if d.config.Workers > 1 && len(records) > 1 {
+		n, err = d.writeConcurrently(ctx, records)
+	} else {
+		n, err = d.writeSequentially(ctx, records)
+	}
+	if err != nil {
+		return n, err
+	}
+
+	if d.throttler != nil {
+		sdk.Logger(ctx).Debug().
+			Float64("rate", d.throttler.Rate()).
+			Int("records", len(records)).
+			Msg("records written")
+	}
+
+	return len(records), nil
+}
+
+func (d *Destination) writeSequentially(ctx context.Context, records []opencdc.Record) (int, error) {
 	for i, record := range records {
-		err := sdk.Util.Destination.Route(
-			ctx,
-			record,
-			d.insert,
-			d.update,
-			d.delete,
-			d.insert,
-		)
+		err := d.write(ctx, record)
 		if err != nil {
 			return i, fmt.Errorf("error routing %v: %w", record.Operation, err)
 		}
@@ -92,11 +177,85 @@ func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int,
 	return len(records), nil
 }
 
-func (d *Destination) Teardown(context.Context) error {
+// write routes a single record to the matching handler, with the headers
+// configured by the record's metadata. If throttling is enabled, the record
+// is retried with a decreased rate for as long as Weaviate reports that the
+// rate limit has been reached.
+func (d *Destination) write(ctx context.Context, record opencdc.Record) error {
+	headers, err := d.recordHeaders(record)
+	if err != nil {
+		return fmt.Errorf("error getting record headers: %w", err)
+	}
+	ctx = weaviate.WithHeaders(ctx, headers)
+
+	ops := d.config.Operations
+	route := func() error {
+		return sdk.Util.Destination.Route(
+			ctx,
+			record,
+			d.handler(ops.Create),
+			d.handler(ops.Update),
+			d.handler(ops.Delete),
+			d.handler(ops.Snapshot),
+		)
+	}
+
+	if d.throttler == nil {
+		return route()
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := d.throttler.Wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = route()
+		if err == nil {
+			if rate, recovered := d.throttler.Success(); recovered {
+				sdk.Logger(ctx).Info().Float64("rate", rate).Msg("write rate recovered to maximum")
+			}
+			return nil
+		}
+		rateLimited := errors.Is(err, weaviate.ErrRateLimited) || errors.Is(err, embedding.ErrRateLimited)
+		if !rateLimited || attempt >= d.config.Throttle.MaxRetries {
+			return err
+		}
+
+		rate := d.throttler.RateLimited()
+		sdk.Logger(ctx).Warn().
+			Err(err).
+			Float64("rate", rate).
+			Int("attempt", attempt+1).
+			Msg("rate limit reached, decreased write rate")
+	}
+}
+
+func (d *Destination) Teardown(ctx context.Context) error {
 	// Teardown signals to the plugin that all records were written and there
 	// will be no more calls to any other function. After Teardown returns, the
 	// plugin should be ready for a graceful shutdown.
-	return nil
+	var errs []error
+	err := d.client.Close()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error closing client: %w", err))
+	}
+
+	// the cache is closed regardless, so that its file isn't left locked
+	if d.cache != nil {
+		hits, misses := d.cache.Stats()
+		sdk.Logger(ctx).Info().
+			Uint64("hits", hits).
+			Uint64("misses", misses).
+			Msg("embedding cache stats")
+
+		err = d.cache.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error closing embedding cache: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (d *Destination) insert(ctx context.Context, record opencdc.Record) error {
@@ -104,8 +263,35 @@ func (d *Destination) insert(ctx context.Context, record opencdc.Record) error {
 	if err != nil {
 		return fmt.Errorf("error creating Weaviate object: %w", err)
 	}
+	if d.config.Chunking.Enabled {
+		return d.writeChunks(ctx, obj)
+	}
+	skip, err := d.skip(ctx, record, obj)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return d.mergeVersion(ctx, obj)
+	}
+	err = d.embed(ctx, []*weaviate.Object{obj})
+	if err != nil {
+		return err
+	}
 
-	return d.client.Insert(ctx, obj)
+	if d.config.SoftDelete.Enabled || d.config.Workers > 1 {
+		// Existing objects are replaced, which revives soft deleted objects.
+		// With multiple workers, records after a failed record may have been
+		// written already, and must not fail when they are retried.
+		err = d.client.Upsert(ctx, []*weaviate.Object{obj})
+	} else {
+		err = d.client.Insert(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+	d.written(ctx, newObjectKey(obj), obj)
+
+	return nil
 }
 
 func (d *Destination) update(ctx context.Context, record opencdc.Record) error {
@@ -113,18 +299,65 @@ func (d *Destination) update(ctx context.Context, record opencdc.Record) error {
 	if err != nil {
 		return fmt.Errorf("error creating Weaviate object: %w", err)
 	}
+	if d.config.Chunking.Enabled {
+		return d.writeChunks(ctx, obj)
+	}
+	skip, err := d.skip(ctx, record, obj)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return d.mergeVersion(ctx, obj)
+	}
+	err = d.embed(ctx, []*weaviate.Object{obj})
+	if err != nil {
+		return err
+	}
 
-	return d.client.Update(ctx, obj)
+	err = d.client.Update(ctx, obj)
+	if err != nil {
+		return err
+	}
+	d.written(ctx, newObjectKey(obj), obj)
+
+	return nil
 }
 
 func (d *Destination) delete(ctx context.Context, record opencdc.Record) error {
-	return d.client.Delete(
-		ctx,
-		&weaviate.Object{
-			ID:    d.recordUUID(record),
-			Class: d.config.Class,
-		},
-	)
+	obj := &weaviate.Object{
+		ID:     d.recordUUID(record),
+		Class:  d.recordClass(record),
+		Tenant: record.Metadata[MetadataTenant],
+	}
+	if d.config.Chunking.Enabled {
+		return d.deleteChunks(ctx, obj, 0)
+	}
+	if d.config.SoftDelete.Enabled {
+		obj.Properties = make(map[string]any)
+	}
+	outdated, err := d.outdated(ctx, record, obj)
+	if err != nil || outdated {
+		return err
+	}
+
+	if d.config.SoftDelete.Enabled {
+		// the stored hash and version are only replaced if an object was
+		// marked as deleted
+		deleted, err := d.softDelete(ctx, record, obj)
+		if err != nil || !deleted {
+			return err
+		}
+		d.written(ctx, newObjectKey(obj), obj)
+		return nil
+	}
+
+	err = d.client.Delete(ctx, obj)
+	if err != nil {
+		return err
+	}
+	d.written(ctx, newObjectKey(obj), nil)
+
+	return nil
 }
 
 func (d *Destination) toWeaviateObj(record opencdc.Record) (*weaviate.Object, error) {
@@ -133,11 +366,6 @@ func (d *Destination) toWeaviateObj(record opencdc.Record) (*weaviate.Object, er
 		return nil, fmt.Errorf("update property conversion: %w", err)
 	}
 
-	class := d.config.Class
-	if record.Metadata != nil && record.Metadata[MetadataClass] != "" {
-		class = record.Metadata[MetadataClass]
-	}
-
 	var vector []float32
 	if record.Metadata != nil && record.Metadata[MetadataVector] != "" {
 		vector, err = d.recordVector(record.Metadata[MetadataVector])
@@ -146,16 +374,47 @@ func (d *Destination) toWeaviateObj(record opencdc.Record) (*weaviate.Object, er
 		}
 	}
 
+	var vectors map[string][]float32
+	for key, value := range record.Metadata {
+		name, ok := strings.CutPrefix(key, MetadataVectorsPrefix)
+		if !ok {
+			continue
+		}
+		v, err := d.recordVector(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing vector %v from metadata, input: %v, error: %w", name, value, err)
+		}
+		if vectors == nil {
+			vectors = make(map[string][]float32)
+		}
+		vectors[name] = v
+	}
+
 	return &weaviate.Object{
 		ID:         d.recordUUID(record),
-		Class:      class,
+		Class:      d.recordClass(record),
 		Properties: properties,
 		Vector:     vector,
+		Vectors:    vectors,
+		Tenant:     record.Metadata[MetadataTenant],
 	}, nil
 }
 
+// recordClass returns the class from the record's metadata, or the
+// configured class.
+func (d *Destination) recordClass(record opencdc.Record) string {
+	if record.Metadata[MetadataClass] != "" {
+		return record.Metadata[MetadataClass]
+	}
+	return d.config.Class
+}
+
 func (d *Destination) recordUUID(record opencdc.Record) string {
 	key := record.Key.Bytes()
+	if d.config.Operations.ID == IDKeyAndPosition {
+		name := slices.Concat(key, []byte{0}, record.Position)
+		return uuid.NewSHA1(uuid.NameSpaceOID, name).String()
+	}
 	if !d.config.GenerateUUID {
 		return string(key)
 	}
@@ -164,42 +423,57 @@ func (d *Destination) recordUUID(record opencdc.Record) string {
 
 func (d *Destination) recordProperties(record opencdc.Record) (map[string]interface{}, error) {
 	data := record.Payload.After
+	// deletes are only written as objects if they are converted to inserts
+	if record.Operation == opencdc.OperationDelete {
+		data = record.Payload.Before
+	}
 
-	if data == nil || len(data.Bytes()) == 0 {
+	properties := make(map[string]interface{})
+	switch {
+	case (data == nil || len(data.Bytes()) == 0) && record.Operation == opencdc.OperationDelete:
+	case data == nil || len(data.Bytes()) == 0:
 		return nil, errors.New("empty payload")
+	default:
+		err := json.Unmarshal(data.Bytes(), &properties)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload to structured data: %w", err)
+		}
 	}
 
-	properties := make(map[string]interface{})
-	err := json.Unmarshal(data.Bytes(), &properties)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal payload to structured data: %w", err)
+	if d.config.Operations.Property != "" {
+		properties[d.config.Operations.Property] = record.Operation.String()
 	}
 
 	return properties, nil
 }
 
-func (d *Destination) weaviateConfig() weaviate.Config {
-	cfg := weaviate.Config{
-		Endpoint: d.config.Endpoint,
-		Scheme:   d.config.Scheme,
+func (d *Destination) weaviateConfig() (weaviate.Config, error) {
+	cfg, err := weaviate.NewConfig(d.config.Config)
+	if err != nil {
+		return weaviate.Config{}, err
 	}
 
-	if d.config.ModuleHeader.IsValid() {
-		cfg.Headers = map[string]string{
-			d.config.ModuleHeader.Name: d.config.ModuleHeader.Value,
+	cfg.Transport = d.config.Transport
+	if d.config.Transport == weaviate.TransportGRPC {
+		address, secure, err := d.config.GRPCAddress()
+		if err != nil {
+			return weaviate.Config{}, err
+		}
+		cfg.GRPC = weaviate.GRPCConfig{
+			Address: address,
+			Secure:  secure,
 		}
 	}
 
-	if d.config.Auth.APIKey != "" {
-		cfg.APIKey = d.config.Auth.APIKey
-	} else {
-		cfg.WCSAuth = weaviate.WCSAuth{
-			Username: d.config.Auth.WCSCredentials.Username,
-			Password: d.config.Auth.WCSCredentials.Password,
-		}
+	headers, err := d.config.ModuleHeaders()
+	if err != nil {
+		return weaviate.Config{}, err
+	}
+	if len(headers) > 0 {
+		cfg.Headers = headers
 	}
 
-	return cfg
+	return cfg, nil
 }
 
 func (d *Destination) recordVector(s string) ([]float32, error) {
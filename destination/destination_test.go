@@ -16,20 +16,31 @@ package destination_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/conduitio-labs/conduit-connector-weaviate/destination/embedding"
 	"github.com/conduitio-labs/conduit-connector-weaviate/destination/mock"
-	"github.com/conduitio-labs/conduit-connector-weaviate/destination/weaviate"
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
 
 	weaviateConn "github.com/conduitio-labs/conduit-connector-weaviate"
 	"github.com/conduitio-labs/conduit-connector-weaviate/destination"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
 	"github.com/matryer/is"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"go.uber.org/mock/gomock"
 )
 
@@ -90,16 +101,20 @@ func TestDestination_Open_WCSAuth(t *testing.T) {
 	client := mock.NewWeaviateClient(ctrl)
 	client.EXPECT().
 		Open(gomock.Eq(weaviate.Config{
+			AuthMechanism: "wcsCreds",
 			WCSAuth: weaviate.WCSAuth{
 				Username: cfg["auth.wcsCreds.username"],
 				Password: cfg["auth.wcsCreds.password"],
 			},
-			Endpoint: cfg["endpoint"],
-			Scheme:   cfg["scheme"],
+			Endpoint:  cfg["endpoint"],
+			Scheme:    cfg["scheme"],
+			Transport: weaviate.TransportHTTP,
+			HTTP:      defaultHTTPConfig,
 			Headers: map[string]string{
 				"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
 			},
 		}))
+	expectPreflight(client)
 
 	underTest := destination.NewWithClient(client)
 	err := sdk.Util.ParseConfig(ctx, cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
@@ -109,6 +124,481 @@ func TestDestination_Open_WCSAuth(t *testing.T) {
 	is.NoErr(err)
 }
 
+func TestDestination_Open_SecretReferences(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	keyFile := filepath.Join(t.TempDir(), "openai-key")
+	is.NoErr(os.WriteFile(keyFile, []byte("test-OpenAI-Api-Key\n"), 0o600))
+	t.Setenv("WEAVIATE_TEST_API_KEY", "test-api-key")
+
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"class":              "test-class",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "env:WEAVIATE_TEST_API_KEY",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "file:" + keyFile,
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewWeaviateClient(ctrl)
+	client.EXPECT().
+		Open(gomock.Eq(weaviate.Config{
+			AuthMechanism: "apiKey",
+			APIKey:        "test-api-key",
+			Endpoint:      "test-endpoint",
+			Scheme:        "https",
+			Transport:     weaviate.TransportHTTP,
+			HTTP:          defaultHTTPConfig,
+			Headers: map[string]string{
+				"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
+			},
+		}))
+	expectPreflight(client)
+
+	underTest := destination.NewWithClient(client)
+	err := sdk.Util.ParseConfig(ctx, cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+	is.NoErr(err)
+
+	err = underTest.Open(ctx)
+	is.NoErr(err)
+}
+
+func TestDestination_Open_SecretReferenceMissing(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	cfg := map[string]string{
+		"endpoint":       "test-endpoint",
+		"class":          "test-class",
+		"auth.mechanism": "apiKey",
+		"auth.apiKey":    "env:WEAVIATE_TEST_MISSING",
+	}
+
+	underTest := destination.NewWithClient(mock.NewWeaviateClient(gomock.NewController(t)))
+	err := sdk.Util.ParseConfig(ctx, cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+	is.NoErr(err)
+
+	err = underTest.Open(ctx)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "error resolving auth.apiKey: environment variable WEAVIATE_TEST_MISSING is not set"))
+}
+
+func TestDestination_Open_GRPC(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      map[string]string
+		wantGRPC weaviate.GRPCConfig
+	}{
+		{
+			name: "host derived from endpoint",
+			cfg: map[string]string{
+				"endpoint":  "weaviate.local:8080",
+				"transport": "grpc",
+			},
+			wantGRPC: weaviate.GRPCConfig{Address: "weaviate.local:50051"},
+		},
+		{
+			name: "custom host and port",
+			cfg: map[string]string{
+				"endpoint":    "weaviate.local",
+				"transport":   "grpc",
+				"grpc.host":   "grpc.weaviate.local",
+				"grpc.port":   "443",
+				"grpc.secure": "true",
+			},
+			wantGRPC: weaviate.GRPCConfig{Address: "grpc.weaviate.local:443", Secure: true},
+		},
+		{
+			name: "gRPC endpoint URL",
+			cfg: map[string]string{
+				"endpoint":      "weaviate.local",
+				"transport":     "grpc",
+				"grpc.endpoint": "https://grpc.weaviate.local:8443",
+			},
+			wantGRPC: weaviate.GRPCConfig{Address: "grpc.weaviate.local:8443", Secure: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+			tc.cfg["class"] = "test-class"
+			tc.cfg["moduleHeader.name"] = "X-OpenAI-Api-Key"
+			tc.cfg["moduleHeader.value"] = "test-OpenAI-Api-Key"
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewWeaviateClient(ctrl)
+			client.EXPECT().
+				Open(gomock.Eq(weaviate.Config{
+					AuthMechanism: "none",
+					Endpoint:      tc.cfg["endpoint"],
+					Scheme:        "https",
+					Transport:     weaviate.TransportGRPC,
+					GRPC:          tc.wantGRPC,
+					HTTP:          defaultHTTPConfig,
+					Headers: map[string]string{
+						"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
+					},
+				}))
+			expectPreflight(client)
+
+			underTest := destination.NewWithClient(client)
+			err := sdk.Util.ParseConfig(ctx, tc.cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+			is.NoErr(err)
+
+			err = underTest.Open(ctx)
+			is.NoErr(err)
+		})
+	}
+}
+
+func TestDestination_Open_EndpointURL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "https://gateway.internal:8443/weaviate/",
+		"scheme":             "http",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewWeaviateClient(ctrl)
+	client.EXPECT().
+		Open(gomock.Eq(weaviate.Config{
+			AuthMechanism: "none",
+			Endpoint:      "gateway.internal:8443/weaviate",
+			Scheme:        "https",
+			Transport:     weaviate.TransportHTTP,
+			HTTP:          defaultHTTPConfig,
+			Headers: map[string]string{
+				"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
+			},
+		}))
+	expectPreflight(client)
+
+	underTest := destination.NewWithClient(client)
+	err := sdk.Util.ParseConfig(ctx, cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+	is.NoErr(err)
+
+	err = underTest.Open(ctx)
+	is.NoErr(err)
+}
+
+func TestDestination_Open_Headers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         map[string]string
+		wantHeaders map[string]string
+		wantErr     string
+	}{
+		{
+			name: "module header, headers and providers",
+			cfg: map[string]string{
+				"moduleHeader.name":             "X-OpenAI-Api-Key",
+				"moduleHeader.value":            "test-OpenAI-Api-Key",
+				"headers.X-OpenAI-Organization": "test-org",
+				"providers.cohere.apiKey":       "test-Cohere-Api-Key",
+				"providers.cohere.baseUrl":      "https://cohere.internal",
+				"providers.jina.apiKey":         "test-JinaAI-Api-Key",
+			},
+			wantHeaders: map[string]string{
+				"X-OpenAI-Api-Key":      "test-OpenAI-Api-Key",
+				"X-OpenAI-Organization": "test-org",
+				"X-Cohere-Api-Key":      "test-Cohere-Api-Key",
+				"X-Cohere-BaseURL":      "https://cohere.internal",
+				"X-JinaAI-Api-Key":      "test-JinaAI-Api-Key",
+			},
+		},
+		{
+			name: "same header with the same value",
+			cfg: map[string]string{
+				"moduleHeader.name":       "X-OpenAI-Api-Key",
+				"moduleHeader.value":      "test-OpenAI-Api-Key",
+				"providers.openai.apiKey": "test-OpenAI-Api-Key",
+			},
+			wantHeaders: map[string]string{
+				"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
+			},
+		},
+		{
+			name: "same header with different values",
+			cfg: map[string]string{
+				"headers.x-openai-api-key": "one",
+				"providers.openai.apiKey":  "two",
+			},
+			wantErr: "is set by both headers.x-openai-api-key and providers.openai.apiKey with different values",
+		},
+		{
+			name: "provider option without API key",
+			cfg: map[string]string{
+				"providers.azureOpenai.resourceName": "my-resource",
+			},
+			wantErr: "providers.azureOpenai.resourceName requires providers.azureOpenai.apiKey",
+		},
+		{
+			name: "invalid header name",
+			cfg: map[string]string{
+				"headers.X-OpenAI Api-Key": "test",
+			},
+			wantErr: `invalid header name "X-OpenAI Api-Key"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+			tc.cfg["endpoint"] = "weaviate.local"
+			tc.cfg["class"] = "test-class"
+			// the module checks would fail for most of the headers
+			tc.cfg["preflight.enabled"] = "false"
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewWeaviateClient(ctrl)
+
+			underTest := destination.NewWithClient(client)
+			err := sdk.Util.ParseConfig(ctx, tc.cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr)) // error is missing the expected message
+				return
+			}
+			is.NoErr(err)
+
+			client.EXPECT().
+				Open(gomock.Eq(weaviate.Config{
+					AuthMechanism: "none",
+					Endpoint:      "weaviate.local",
+					Scheme:        "https",
+					Transport:     weaviate.TransportHTTP,
+					HTTP:          defaultHTTPConfig,
+					Headers:       tc.wantHeaders,
+				}))
+
+			err = underTest.Open(ctx)
+			is.NoErr(err)
+		})
+	}
+}
+
+func TestDestination_Open_HTTP(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":                 "weaviate.local",
+		"class":                    "test-class",
+		"moduleHeader.name":        "X-OpenAI-Api-Key",
+		"moduleHeader.value":       "test-OpenAI-Api-Key",
+		"http.timeout":             "5s",
+		"http.connectTimeout":      "1s",
+		"http.proxyUrl":            "http://proxy.local:3128",
+		"http.maxIdleConnsPerHost": "32",
+		"http.gzip":                "true",
+	}
+
+	ctrl := gomock.NewController(t)
+	client := mock.NewWeaviateClient(ctrl)
+	client.EXPECT().
+		Open(gomock.Eq(weaviate.Config{
+			AuthMechanism: "none",
+			Endpoint:      cfg["endpoint"],
+			Scheme:        "https",
+			Transport:     weaviate.TransportHTTP,
+			HTTP: weaviate.HTTPConfig{
+				Timeout:             5 * time.Second,
+				ConnectTimeout:      time.Second,
+				ProxyURL:            "http://proxy.local:3128",
+				MaxIdleConnsPerHost: 32,
+				Gzip:                true,
+			},
+			Headers: map[string]string{
+				"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
+			},
+		}))
+	expectPreflight(client)
+
+	underTest := destination.NewWithClient(client)
+	err := sdk.Util.ParseConfig(ctx, cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+	is.NoErr(err)
+
+	err = underTest.Open(ctx)
+	is.NoErr(err)
+}
+
+func TestDestination_Open_Preflight(t *testing.T) {
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-Cohere-Api-Key",
+		"moduleHeader.value": "test-Cohere-Api-Key",
+	}
+	meta := &weaviate.Meta{Version: "1.27.0", Modules: []string{"text2vec-cohere"}}
+
+	testCases := []struct {
+		name     string
+		cfg      map[string]string
+		setup    func(client *mock.WeaviateClient)
+		wantErrs []string
+	}{
+		{
+			name: "all checks pass",
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(meta, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(true, nil)
+			},
+		},
+		{
+			name: "not reachable",
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(false, errors.New("connection refused"))
+			},
+			wantErrs: []string{"could not reach Weaviate at https://test-endpoint", "connection refused"},
+		},
+		{
+			name: "not ready",
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(false, nil)
+			},
+			wantErrs: []string{"not ready to serve requests"},
+		},
+		{
+			name: "credentials rejected",
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(nil, weaviate.ErrUnauthorized)
+			},
+			wantErrs: []string{"credentials were rejected"},
+		},
+		{
+			name: "version, module and class checks fail",
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(&weaviate.Meta{
+					Version: "1.18.2",
+					Modules: []string{"text2vec-openai"},
+				}, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(false, nil)
+			},
+			wantErrs: []string{
+				"unsupported Weaviate version 1.18.2",
+				"configures a cohere module",
+			},
+		},
+		{
+			name: "missing class is left to auto-schema",
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(meta, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(false, nil)
+			},
+		},
+		{
+			name: "module of provider header is not enabled",
+			cfg:  map[string]string{"providers.voyageai.apiKey": "test-VoyageAI-Api-Key"},
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(meta, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(true, nil)
+			},
+			wantErrs: []string{"header X-VoyageAI-Api-Key configures a voyageai module"},
+		},
+		{
+			name: "google header with the google module",
+			cfg: map[string]string{
+				"moduleHeader.name":  "X-Google-Studio-Api-Key",
+				"moduleHeader.value": "test-Google-Api-Key",
+			},
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(&weaviate.Meta{
+					Version: "1.28.0",
+					Modules: []string{"text2vec-google", "generative-google"},
+				}, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(true, nil)
+			},
+		},
+		{
+			name: "google header without a google module",
+			cfg: map[string]string{
+				"moduleHeader.name":  "X-Google-Api-Key",
+				"moduleHeader.value": "test-Google-Api-Key",
+			},
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(meta, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(true, nil)
+			},
+			wantErrs: []string{"header X-Google-Api-Key configures a google or palm module"},
+		},
+		{
+			name: "class is created",
+			cfg:  map[string]string{"preflight.createClass": "true"},
+			setup: func(client *mock.WeaviateClient) {
+				client.EXPECT().Live(gomock.Any()).Return(true, nil)
+				client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+				client.EXPECT().Meta(gomock.Any()).Return(meta, nil)
+				client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(false, nil)
+				client.EXPECT().CreateClass(gomock.Any(), "test-class").Return(nil)
+			},
+		},
+		{
+			name: "checks disabled",
+			cfg:  map[string]string{"preflight.enabled": "false"},
+			setup: func(*mock.WeaviateClient) {
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			client := mock.NewWeaviateClient(ctrl)
+			client.EXPECT().Open(gomock.Any())
+			tc.setup(client)
+
+			tcCfg := make(map[string]string)
+			for k, v := range cfg {
+				tcCfg[k] = v
+			}
+			for k, v := range tc.cfg {
+				tcCfg[k] = v
+			}
+
+			underTest := destination.NewWithClient(client)
+			err := sdk.Util.ParseConfig(ctx, tcCfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+			is.NoErr(err)
+
+			err = underTest.Open(ctx)
+			if len(tc.wantErrs) == 0 {
+				is.NoErr(err)
+				return
+			}
+
+			is.True(err != nil)
+			for _, want := range tc.wantErrs {
+				is.True(strings.Contains(err.Error(), want)) // error is missing the expected message
+			}
+		})
+	}
+}
+
 func TestDestination_Open_OpensClient(t *testing.T) {
 	ctx := context.Background()
 	cfg := map[string]string{
@@ -322,19 +812,198 @@ func TestDestination_RecordWithVector(t *testing.T) {
 	}
 }
 
+func TestDestination_RecordHeaders(t *testing.T) {
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"scheme":             "https",
+		"class":              "test-class",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "test-api-key",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+		"secrets.customer-a": "sk-customer-a",
+		"secrets.org-a":      "org-customer-a",
+	}
+
+	testCases := []struct {
+		name        string
+		metadata    map[string]string
+		wantHeaders map[string]string
+		wantErr     string
+	}{
+		{
+			name:     "no header metadata",
+			metadata: map[string]string{},
+		},
+		{
+			name: "headers from secrets",
+			metadata: map[string]string{
+				destination.MetadataHeaderPrefix + "X-OpenAI-Api-Key":      "customer-a",
+				destination.MetadataHeaderPrefix + "X-OpenAI-Organization": "org-a",
+			},
+			wantHeaders: map[string]string{
+				"X-OpenAI-Api-Key":      "sk-customer-a",
+				"X-OpenAI-Organization": "org-customer-a",
+			},
+		},
+		{
+			name: "unknown secret",
+			metadata: map[string]string{
+				destination.MetadataHeaderPrefix + "X-OpenAI-Api-Key": "sk-raw-key",
+			},
+			wantErr: `metadata field weaviate.header.X-OpenAI-Api-Key references unknown secret "sk-raw-key"`,
+		},
+		{
+			name: "authorization header",
+			metadata: map[string]string{
+				destination.MetadataHeaderPrefix + "authorization": "customer-a",
+			},
+			wantErr: "the Authorization header can't be set per record",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			underTest, wClient := setupTest(t, ctx, cfg)
+			rec := sdk.Util.Source.NewRecordCreate(
+				opencdc.Position("test-position"),
+				tc.metadata,
+				opencdc.RawData("f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"),
+				opencdc.StructuredData{"product_name": "computer"},
+			)
+
+			if tc.wantErr == "" {
+				wClient.EXPECT().
+					Insert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, _ *weaviate.Object) error {
+						is.Equal(weaviate.HeadersFromContext(ctx), tc.wantHeaders)
+						return nil
+					})
+			}
+
+			n, err := underTest.Write(ctx, []opencdc.Record{rec})
+			if tc.wantErr == "" {
+				is.NoErr(err)
+				is.Equal(n, 1)
+				return
+			}
+
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.wantErr)) // error is missing the expected message
+			is.True(!strings.Contains(err.Error(), "sk-customer-a"))
+			is.Equal(n, 0)
+		})
+	}
+}
+
+func TestDestination_Workers(t *testing.T) {
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"scheme":             "https",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "test-api-key",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+		"workers":            "4",
+	}
+
+	// 10 keys, every key gets created, updated and deleted
+	var records []opencdc.Record
+	for _, op := range []opencdc.Operation{opencdc.OperationCreate, opencdc.OperationUpdate, opencdc.OperationDelete} {
+		for k := 0; k < 10; k++ {
+			records = append(records, opencdc.Record{
+				Position:  opencdc.Position(fmt.Sprintf("%v-%v", op, k)),
+				Operation: op,
+				Key:       opencdc.RawData(fmt.Sprintf("key-%v", k)),
+				Payload: opencdc.Change{
+					After: opencdc.StructuredData{"op": op.String()},
+				},
+			})
+		}
+	}
+
+	t.Run("operations on the same key are ordered", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+
+		var mu sync.Mutex
+		got := make(map[string][]string)
+		recordOp := func(op string) func(context.Context, *weaviate.Object) error {
+			return func(_ context.Context, obj *weaviate.Object) error {
+				mu.Lock()
+				defer mu.Unlock()
+				got[obj.ID] = append(got[obj.ID], op)
+				return nil
+			}
+		}
+		// inserts replace existing objects, as records may be written again
+		// when they are retried
+		wClient.EXPECT().Upsert(ctx, gomock.Len(1)).
+			DoAndReturn(func(ctx context.Context, objs []*weaviate.Object) error {
+				return recordOp("create")(ctx, objs[0])
+			}).Times(10)
+		wClient.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(recordOp("update")).Times(10)
+		wClient.EXPECT().Delete(ctx, gomock.Any()).DoAndReturn(recordOp("delete")).Times(10)
+
+		n, err := underTest.Write(ctx, records)
+		is.NoErr(err)
+		is.Equal(len(records), n)
+		is.Equal(len(got), 10)
+		for _, ops := range got {
+			is.Equal([]string{"create", "update", "delete"}, ops)
+		}
+	})
+
+	t.Run("returns index of first failed record", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+
+		wantErr := errors.New("update failed")
+		wClient.EXPECT().Upsert(ctx, gomock.Any()).Return(nil).Times(10)
+		wClient.EXPECT().Update(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+				if obj.ID == "key-3" {
+					return wantErr
+				}
+				return nil
+			}).AnyTimes()
+		wClient.EXPECT().Delete(ctx, gomock.Any()).Return(nil).AnyTimes()
+
+		n, err := underTest.Write(ctx, records)
+		is.True(errors.Is(err, wantErr))
+		is.Equal(13, n) // 10 creates and updates of key-0 to key-2
+	})
+}
+
+// defaultHTTPConfig is the HTTP configuration resulting from the default
+// parameter values.
+var defaultHTTPConfig = weaviate.HTTPConfig{
+	Timeout:             time.Minute,
+	ConnectTimeout:      30 * time.Second,
+	MaxIdleConnsPerHost: 10,
+}
+
 func setupTest(t *testing.T, ctx context.Context, cfg map[string]string) (sdk.Destination, *mock.WeaviateClient) {
 	is := is.New(t)
 	ctrl := gomock.NewController(t)
 	client := mock.NewWeaviateClient(ctrl)
 	client.EXPECT().
 		Open(gomock.Eq(weaviate.Config{
-			APIKey:   cfg["auth.apiKey"],
-			Endpoint: cfg["endpoint"],
-			Scheme:   cfg["scheme"],
+			AuthMechanism: cfg["auth.mechanism"],
+			APIKey:        cfg["auth.apiKey"],
+			Endpoint:      cfg["endpoint"],
+			Scheme:        cfg["scheme"],
+			Transport:     weaviate.TransportHTTP,
+			HTTP:          defaultHTTPConfig,
 			Headers: map[string]string{
 				"X-OpenAI-Api-Key": "test-OpenAI-Api-Key",
 			},
 		}))
+	expectPreflight(client)
 
 	underTest := destination.NewWithClient(client)
 	err := sdk.Util.ParseConfig(ctx, cfg, underTest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
@@ -345,3 +1014,871 @@ func setupTest(t *testing.T, ctx context.Context, cfg map[string]string) (sdk.De
 
 	return underTest, client
 }
+
+// expectPreflight expects the preflight checks to run once, and sets up the
+// client to pass them.
+func expectPreflight(client *mock.WeaviateClient) {
+	client.EXPECT().Live(gomock.Any()).Return(true, nil)
+	client.EXPECT().Ready(gomock.Any()).Return(true, nil)
+	client.EXPECT().Meta(gomock.Any()).Return(&weaviate.Meta{
+		Version: "1.27.0",
+		Modules: []string{"generative-openai", "text2vec-openai"},
+	}, nil)
+	client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(true, nil)
+}
+
+// expectClass expects the schema of test-class to be fetched, and returns
+// it with the text properties.
+func expectClass(client *mock.WeaviateClient, properties ...string) {
+	client.EXPECT().ClassExists(gomock.Any(), "test-class").Return(true, nil)
+	cls := &weaviate.Class{Name: "test-class"}
+	for _, p := range properties {
+		cls.Properties = append(cls.Properties, weaviate.Property{Name: p, DataType: []string{"text"}})
+	}
+	client.EXPECT().Class(gomock.Any(), "test-class").Return(cls, nil)
+}
+
+func TestDestination_Throttle(t *testing.T) {
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":             "test-endpoint",
+		"scheme":               "https",
+		"auth.mechanism":       "apiKey",
+		"auth.apiKey":          "test-api-key",
+		"class":                "test-class",
+		"moduleHeader.name":    "X-OpenAI-Api-Key",
+		"moduleHeader.value":   "test-OpenAI-Api-Key",
+		"throttle.enabled":     "true",
+		"throttle.initialRate": "1000",
+		"throttle.minRate":     "500",
+		"throttle.maxRate":     "1000",
+		"throttle.maxRetries":  "2",
+	}
+	rateLimitErr := fmt.Errorf("error creating object: %w", weaviate.ErrRateLimited)
+	record := sdk.Util.Source.NewRecordCreate(
+		opencdc.Position("test-position"),
+		map[string]string{},
+		opencdc.RawData("f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"),
+		opencdc.StructuredData{"product_name": "computer"},
+	)
+
+	t.Run("retries rate-limited records", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		gomock.InOrder(
+			wClient.EXPECT().Insert(ctx, gomock.Any()).Return(rateLimitErr).Times(2),
+			wClient.EXPECT().Insert(ctx, gomock.Any()).Return(nil),
+		)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{record})
+		is.NoErr(err)
+		is.Equal(1, n)
+	})
+
+	t.Run("fails after max retries", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		wClient.EXPECT().Insert(ctx, gomock.Any()).Return(rateLimitErr).Times(3)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{record})
+		is.True(errors.Is(err, weaviate.ErrRateLimited))
+		is.Equal(0, n)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		wantErr := errors.New("boom")
+		wClient.EXPECT().Insert(ctx, gomock.Any()).Return(wantErr)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{record})
+		is.True(errors.Is(err, wantErr))
+		is.Equal(0, n)
+	})
+}
+
+func TestDestination_Chunking(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"scheme":             "https",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "test-api-key",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+		"chunking.enabled":   "true",
+		"chunking.property":  "body",
+		"chunking.unit":      "sentences",
+		"chunking.size":      "2",
+		"chunking.overlap":   "1",
+	}
+	const id = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+	chunkID := func(i int) string {
+		return uuid.NewSHA1(uuid.MustParse(id), []byte(fmt.Sprint(i))).String()
+	}
+	payload := opencdc.StructuredData{"title": "doc", "body": "One. Two!  Three? Four"}
+	wantChunks := []*weaviate.Object{{
+		ID:         chunkID(0),
+		Class:      "test-class",
+		Properties: map[string]any{"title": "doc", "body": "One. Two!", "parentId": id, "chunkIndex": 0},
+		Tenant:     "tenantA",
+	}, {
+		ID:         chunkID(1),
+		Class:      "test-class",
+		Properties: map[string]any{"title": "doc", "body": "Two! Three?", "parentId": id, "chunkIndex": 1},
+		Tenant:     "tenantA",
+	}, {
+		ID:         chunkID(2),
+		Class:      "test-class",
+		Properties: map[string]any{"title": "doc", "body": "Three? Four", "parentId": id, "chunkIndex": 2},
+		Tenant:     "tenantA",
+	}}
+	metadata := map[string]string{
+		destination.MetadataTenant: "tenantA",
+		destination.MetadataVector: "0.1,0.2",
+	}
+
+	deleteFrom := func(from int) func(context.Context, string, string, *filters.WhereBuilder) error {
+		return func(_ context.Context, _, _ string, where *filters.WhereBuilder) error {
+			is.Equal(where.String(), `where:{operator: And operands:[`+
+				`{operator: Equal path: ["parentId"] valueText: "`+id+`"},`+
+				`{operator: GreaterThanEqual path: ["chunkIndex"] valueNumber: `+fmt.Sprint(from)+`}]}`)
+			return nil
+		}
+	}
+
+	underTest, wClient := setupTest(t, ctx, cfg)
+	// chunkIndex was created by auto-schema, so it's a number
+	wClient.EXPECT().ClassExists(ctx, "test-class").Return(true, nil)
+	wClient.EXPECT().Class(ctx, "test-class").Return(&weaviate.Class{
+		Name: "test-class",
+		Properties: []weaviate.Property{
+			{Name: "parentId", DataType: []string{"text"}},
+			{Name: "chunkIndex", DataType: []string{"number"}},
+		},
+	}, nil)
+	gomock.InOrder(
+		// a create writes the chunks and deletes the ones after the last
+		// chunk, left over from a previous version of the object
+		wClient.EXPECT().Upsert(ctx, newEqMatcher(wantChunks)),
+		wClient.EXPECT().DeleteWhere(ctx, "test-class", "tenantA", gomock.Any()).
+			DoAndReturn(deleteFrom(3)),
+		// so does an update
+		wClient.EXPECT().Upsert(ctx, newEqMatcher(wantChunks[:1])),
+		wClient.EXPECT().DeleteWhere(ctx, "test-class", "tenantA", gomock.Any()).
+			DoAndReturn(deleteFrom(1)),
+		// a delete deletes all chunks
+		wClient.EXPECT().DeleteWhere(ctx, "test-class", "tenantA", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, where *filters.WhereBuilder) error {
+				is.Equal(where.String(), `where:{operator: Equal path: ["parentId"] valueText: "`+id+`"}`)
+				return nil
+			}),
+	)
+
+	n, err := underTest.Write(ctx, []opencdc.Record{
+		sdk.Util.Source.NewRecordCreate(nil, metadata, opencdc.RawData(id), payload),
+		sdk.Util.Source.NewRecordUpdate(nil, metadata, opencdc.RawData(id), nil, opencdc.StructuredData{"title": "doc", "body": "One. Two!"}),
+		sdk.Util.Source.NewRecordDelete(nil, metadata, opencdc.RawData(id), nil),
+	})
+	is.NoErr(err)
+	is.Equal(n, 3)
+}
+
+func TestDestination_Chunking_Units(t *testing.T) {
+	ctx := context.Background()
+	const id = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+
+	testCases := []struct {
+		name    string
+		unit    string
+		size    string
+		overlap string
+		text    any
+		want    []string
+	}{{
+		name:    "characters",
+		unit:    "characters",
+		size:    "4",
+		overlap: "1",
+		text:    "abcdéfghij",
+		want:    []string{"abcd", "défg", "ghij"},
+	}, {
+		name: "tokens",
+		unit: "tokens",
+		size: "3",
+		text: " the quick\nbrown  fox jumps ",
+		want: []string{"the quick brown", "fox jumps"},
+	}, {
+		name: "missing text",
+		unit: "tokens",
+		size: "3",
+		text: nil,
+		want: nil,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			underTest, wClient := setupTest(t, ctx, map[string]string{
+				"endpoint":           "test-endpoint",
+				"scheme":             "https",
+				"auth.mechanism":     "apiKey",
+				"auth.apiKey":        "test-api-key",
+				"class":              "test-class",
+				"moduleHeader.name":  "X-OpenAI-Api-Key",
+				"moduleHeader.value": "test-OpenAI-Api-Key",
+				"chunking.enabled":   "true",
+				"chunking.property":  "text",
+				"chunking.unit":      tc.unit,
+				"chunking.size":      tc.size,
+				"chunking.overlap":   tc.overlap,
+			})
+			// no chunks were written yet, so there are none to delete
+			wClient.EXPECT().ClassExists(ctx, "test-class").Return(true, nil)
+			wClient.EXPECT().Class(ctx, "test-class").Return(&weaviate.Class{Name: "test-class"}, nil)
+			var got []string
+			wClient.EXPECT().Upsert(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, objs []*weaviate.Object) error {
+					for _, obj := range objs {
+						got = append(got, obj.Properties["text"].(string))
+					}
+					return nil
+				}).
+				MaxTimes(1)
+
+			_, err := underTest.Write(ctx, []opencdc.Record{
+				sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id), opencdc.StructuredData{"text": tc.text}),
+			})
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestDestination_Embedding(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":             "test-endpoint",
+		"scheme":               "https",
+		"auth.mechanism":       "apiKey",
+		"auth.apiKey":          "test-api-key",
+		"class":                "test-class",
+		"moduleHeader.name":    "X-OpenAI-Api-Key",
+		"moduleHeader.value":   "test-OpenAI-Api-Key",
+		"embedding.provider":   "hashing",
+		"embedding.properties": "title,body",
+		"embedding.dimensions": "8",
+	}
+	const id = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+	want, err := embedding.NewHashing(8).Embed(ctx, []string{"doc\nsome text"})
+	is.NoErr(err)
+
+	underTest, wClient := setupTest(t, ctx, cfg)
+	gomock.InOrder(
+		wClient.EXPECT().Insert(gomock.Any(), newEqMatcher(&weaviate.Object{
+			ID:         id,
+			Class:      "test-class",
+			Properties: map[string]any{"title": "doc", "body": "some text"},
+			Vector:     want[0],
+		})),
+		// the vector of the record is kept
+		wClient.EXPECT().Update(gomock.Any(), newEqMatcher(&weaviate.Object{
+			ID:         id,
+			Class:      "test-class",
+			Properties: map[string]any{"title": "doc", "body": "some text"},
+			Vector:     []float32{0.1, 0.2},
+		})),
+	)
+
+	n, err := underTest.Write(ctx, []opencdc.Record{
+		sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id), opencdc.StructuredData{"title": "doc", "body": "some text"}),
+		sdk.Util.Source.NewRecordUpdate(nil, map[string]string{destination.MetadataVector: "0.1,0.2"}, opencdc.RawData(id), nil, opencdc.StructuredData{"title": "doc", "body": "some text"}),
+	})
+	is.NoErr(err)
+	is.Equal(n, 2)
+}
+
+func TestDestination_Embedding_OpenAI(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	// the stand-in embeds a text as a vector of its length
+	var inputs [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		is.Equal(r.Header.Get("Authorization"), "Bearer test-key")
+		var req struct {
+			Input []string `json:"input"`
+		}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&req))
+		inputs = append(inputs, req.Input)
+
+		data := make([]map[string]any, len(req.Input))
+		for i, text := range req.Input {
+			data[i] = map[string]any{"index": i, "embedding": []float32{float32(len(text))}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	cfg := map[string]string{
+		"endpoint":                 "test-endpoint",
+		"scheme":                   "https",
+		"auth.mechanism":           "apiKey",
+		"auth.apiKey":              "test-api-key",
+		"class":                    "test-class",
+		"moduleHeader.name":        "X-OpenAI-Api-Key",
+		"moduleHeader.value":       "test-OpenAI-Api-Key",
+		"embedding.provider":       "openai",
+		"embedding.properties":     "text",
+		"embedding.vector":         "content",
+		"embedding.batchSize":      "2",
+		"embedding.openai.baseUrl": srv.URL,
+		"embedding.openai.apiKey":  "test-key",
+	}
+
+	underTest, wClient := setupTest(t, ctx, cfg)
+	var got []map[string][]float32
+	wClient.EXPECT().Insert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+			got = append(got, obj.Vectors)
+			return nil
+		}).
+		Times(4)
+
+	var records []opencdc.Record
+	for _, text := range []string{"a", "bb", "a", "ccc"} {
+		records = append(records, sdk.Util.Source.NewRecordCreate(
+			nil, nil, opencdc.RawData(uuid.NewString()), opencdc.StructuredData{"text": text},
+		))
+	}
+	n, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+	is.Equal(n, 4)
+
+	// the texts of all records are embedded once, in batches
+	is.Equal(inputs, [][]string{{"a", "bb"}, {"ccc"}})
+	is.Equal(got, []map[string][]float32{
+		{"content": {1}}, {"content": {2}}, {"content": {1}}, {"content": {3}},
+	})
+}
+
+func TestDestination_Embedding_Cache(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var inputs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&req))
+		inputs = append(inputs, req.Input...)
+
+		data := make([]map[string]any, len(req.Input))
+		for i, text := range req.Input {
+			data[i] = map[string]any{"index": i, "embedding": []float32{float32(len(text))}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	cfg := map[string]string{
+		"endpoint":                 "test-endpoint",
+		"scheme":                   "https",
+		"auth.mechanism":           "apiKey",
+		"auth.apiKey":              "test-api-key",
+		"class":                    "test-class",
+		"moduleHeader.name":        "X-OpenAI-Api-Key",
+		"moduleHeader.value":       "test-OpenAI-Api-Key",
+		"embedding.provider":       "openai",
+		"embedding.properties":     "text",
+		"embedding.openai.baseUrl": srv.URL,
+		"embedding.cache.enabled":  "true",
+		"embedding.cache.path":     filepath.Join(t.TempDir(), "cache.db"),
+	}
+	records := func(texts ...string) []opencdc.Record {
+		var records []opencdc.Record
+		for _, text := range texts {
+			records = append(records, sdk.Util.Source.NewRecordSnapshot(
+				nil, nil, opencdc.RawData(uuid.NewString()), opencdc.StructuredData{"text": text},
+			))
+		}
+		return records
+	}
+
+	// the cache persists across restarts, only new texts are embedded
+	for _, texts := range [][]string{{"a", "bb"}, {"bb", "ccc", "a"}} {
+		underTest, wClient := setupTest(t, ctx, cfg)
+		var got [][]float32
+		wClient.EXPECT().Insert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+				got = append(got, obj.Vector)
+				return nil
+			}).
+			Times(len(texts))
+		wClient.EXPECT().Close()
+
+		n, err := underTest.Write(ctx, records(texts...))
+		is.NoErr(err)
+		is.Equal(n, len(texts))
+		for i, text := range texts {
+			is.Equal(got[i], []float32{float32(len(text))})
+		}
+		is.NoErr(underTest.Teardown(ctx))
+	}
+	is.Equal(inputs, []string{"a", "bb", "ccc"})
+}
+
+func TestDestination_Teardown_ClosesCache(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":                "test-endpoint",
+		"scheme":                  "https",
+		"auth.mechanism":          "apiKey",
+		"auth.apiKey":             "test-api-key",
+		"class":                   "test-class",
+		"moduleHeader.name":       "X-OpenAI-Api-Key",
+		"moduleHeader.value":      "test-OpenAI-Api-Key",
+		"embedding.provider":      "openai",
+		"embedding.properties":    "text",
+		"embedding.cache.enabled": "true",
+		"embedding.cache.path":    filepath.Join(t.TempDir(), "cache.db"),
+	}
+
+	underTest, wClient := setupTest(t, ctx, cfg)
+	wClient.EXPECT().Close().Return(errors.New("connection reset"))
+	err := underTest.Teardown(ctx)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "error closing client: connection reset"))
+
+	// the cache was closed, so it can be opened again
+	underTest, wClient = setupTest(t, ctx, cfg)
+	wClient.EXPECT().Close()
+	is.NoErr(underTest.Teardown(ctx))
+}
+
+func TestDestination_ContentHash(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":            "test-endpoint",
+		"scheme":              "https",
+		"auth.mechanism":      "apiKey",
+		"auth.apiKey":         "test-api-key",
+		"class":               "test-class",
+		"moduleHeader.name":   "X-OpenAI-Api-Key",
+		"moduleHeader.value":  "test-OpenAI-Api-Key",
+		"contentHash.enabled": "true",
+	}
+	const (
+		id1 = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+		id2 = "53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e"
+	)
+	underTest, wClient := setupTest(t, ctx, cfg)
+
+	// the first write stores the hashes, the hash property doesn't exist
+	// before that
+	hashes := make(map[string]string)
+	expectClass(wClient, "name")
+	wClient.EXPECT().Insert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+			hash, ok := obj.Properties["_contentHash"].(string)
+			is.True(ok)
+			hashes[obj.ID] = hash
+			return nil
+		}).
+		Times(2)
+
+	n, err := underTest.Write(ctx, []opencdc.Record{
+		sdk.Util.Source.NewRecordSnapshot(nil, nil, opencdc.RawData(id1), opencdc.StructuredData{"name": "a"}),
+		sdk.Util.Source.NewRecordSnapshot(nil, map[string]string{destination.MetadataVector: "0.1,0.2"}, opencdc.RawData(id2), opencdc.StructuredData{"name": "b"}),
+	})
+	is.NoErr(err)
+	is.Equal(n, 2)
+	is.True(hashes[id1] != hashes[id2])
+
+	// unchanged objects are skipped, also if the record contains the hash
+	// read from Weaviate, objects written twice in a batch are compared to
+	// the hash written by the first record
+	expectClass(wClient, "name", "_contentHash")
+	wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_contentHash"}, []string{id1, id2}).
+		Return(map[string]map[string]string{
+			id1: {"_contentHash": hashes[id1]},
+			id2: {"_contentHash": hashes[id2]},
+		}, nil)
+	gomock.InOrder(
+		wClient.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+				is.Equal(obj.Properties["name"], "c")
+				is.True(obj.Properties["_contentHash"] != hashes[id1])
+				return nil
+			}),
+		wClient.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+				is.Equal(obj.Properties["name"], "a")
+				is.Equal(obj.Properties["_contentHash"], hashes[id1])
+				return nil
+			}),
+	)
+
+	n, err = underTest.Write(ctx, []opencdc.Record{
+		sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": "c"}),
+		sdk.Util.Source.NewRecordUpdate(nil, map[string]string{destination.MetadataVector: "0.1,0.2"}, opencdc.RawData(id2), nil, opencdc.StructuredData{"name": "b", "_contentHash": "stale"}),
+		sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": "a"}),
+		sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": "a"}),
+	})
+	is.NoErr(err)
+	is.Equal(n, 4)
+}
+
+func TestDestination_Version(t *testing.T) {
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"scheme":             "https",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "test-api-key",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+		"versionField":       "updatedAt",
+	}
+	const (
+		id1 = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+		id2 = "53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e"
+	)
+	update := func(id string, version any) opencdc.Record {
+		return sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id), nil, opencdc.StructuredData{"updatedAt": version})
+	}
+	object := func(id string, version any, stored string) *weaviate.Object {
+		return &weaviate.Object{
+			ID:         id,
+			Class:      "test-class",
+			Properties: map[string]any{"updatedAt": version, "_version": stored},
+		}
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		expectClass(wClient, "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_version"}, []string{id1, id2}).
+			Return(map[string]map[string]string{id1: {"_version": "5"}}, nil)
+		gomock.InOrder(
+			wClient.EXPECT().Update(gomock.Any(), newEqMatcher(object(id1, float64(7), "7"))),
+			wClient.EXPECT().Insert(gomock.Any(), newEqMatcher(object(id2, "2026-01-01T00:00:00Z", "1767225600000000000"))),
+			wClient.EXPECT().Delete(gomock.Any(), newEqMatcher(&weaviate.Object{ID: id2, Class: "test-class"})),
+		)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{
+			// older than the stored version
+			update(id1, 3),
+			update(id1, 7),
+			// older than the version written by the previous record
+			update(id1, 6),
+			sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id2), opencdc.StructuredData{"updatedAt": "2026-01-01T00:00:00Z"}),
+			// deletes are compared using the payload before the change
+			sdk.Util.Source.NewRecordDelete(nil, nil, opencdc.RawData(id1), opencdc.StructuredData{"updatedAt": 6}),
+			// deletes without a version are always applied
+			sdk.Util.Source.NewRecordDelete(nil, nil, opencdc.RawData(id2), nil),
+		})
+		is.NoErr(err)
+		is.Equal(n, 6)
+	})
+
+	t.Run("metadata field", func(t *testing.T) {
+		is := is.New(t)
+		cfg := maps.Clone(cfg)
+		cfg["versionField"] = "metadata.opencdc.readAt"
+		underTest, wClient := setupTest(t, ctx, cfg)
+		expectClass(wClient, "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_version"}, []string{id1}).
+			Return(map[string]map[string]string{id1: {"_version": "1767225600000000001"}}, nil)
+		wClient.EXPECT().Update(gomock.Any(), newEqMatcher(&weaviate.Object{
+			ID:         id1,
+			Class:      "test-class",
+			Properties: map[string]any{"name": "b", "_version": "1767225600000000002"},
+		}))
+
+		// the read time is set when records are created
+		records := []opencdc.Record{
+			sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": "a"}),
+			sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": "b"}),
+		}
+		records[0].Metadata[opencdc.MetadataReadAt] = "1767225600000000000"
+		records[1].Metadata[opencdc.MetadataReadAt] = "1767225600000000002"
+
+		n, err := underTest.Write(ctx, records)
+		is.NoErr(err)
+		is.Equal(n, 2)
+	})
+
+	t.Run("content hash", func(t *testing.T) {
+		is := is.New(t)
+		cfg := maps.Clone(cfg)
+		cfg["versionField"] = "metadata.v"
+		cfg["contentHash.enabled"] = "true"
+		underTest, wClient := setupTest(t, ctx, cfg)
+		record := func(version, name string) opencdc.Record {
+			return sdk.Util.Source.NewRecordUpdate(nil, map[string]string{"v": version}, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": name})
+		}
+
+		var hash string
+		expectClass(wClient, "name", "_contentHash", "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_contentHash", "_version"}, []string{id1}).Return(nil, nil)
+		wClient.EXPECT().Update(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+				is.Equal(obj.Properties["_version"], "5")
+				hash = obj.Properties["_contentHash"].(string)
+				return nil
+			})
+
+		n, err := underTest.Write(ctx, []opencdc.Record{record("5", "a")})
+		is.NoErr(err)
+		is.Equal(n, 1)
+
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_contentHash", "_version"}, []string{id1}).
+			Return(map[string]map[string]string{id1: {"_contentHash": hash, "_version": "5"}}, nil)
+		// an unchanged object with a newer version only gets the version,
+		// so that older records are still outdated
+		wClient.EXPECT().Merge(gomock.Any(), newEqMatcher(&weaviate.Object{
+			ID:         id1,
+			Class:      "test-class",
+			Properties: map[string]any{"_version": "9"},
+		}))
+
+		n, err = underTest.Write(ctx, []opencdc.Record{
+			// a replay of the written record is skipped
+			record("5", "a"),
+			record("9", "a"),
+			record("7", "b"),
+		})
+		is.NoErr(err)
+		is.Equal(n, 3)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		expectClass(wClient, "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_version"}, []string{id1}).
+			Return(nil, errors.New("boom"))
+
+		// without the stored versions, outdated records can't be recognized
+		n, err := underTest.Write(ctx, []opencdc.Record{update(id1, 7)})
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "error fetching stored properties of class test-class: boom"))
+		is.Equal(n, 0)
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		expectClass(wClient, "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_version"}, []string{id1}).Return(nil, nil)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{update(id1, nil)})
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "record has no version field updatedAt"))
+		is.Equal(n, 0)
+	})
+}
+
+func TestDestination_SoftDelete(t *testing.T) {
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"scheme":             "https",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "test-api-key",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+		"softDelete.enabled": "true",
+	}
+	const (
+		id1 = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+		id2 = "53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e"
+	)
+	readAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	deleteRecord := func(id string, before opencdc.Data) opencdc.Record {
+		record := sdk.Util.Source.NewRecordDelete(nil, nil, opencdc.RawData(id), before)
+		record.Metadata.SetReadAt(readAt)
+		return record
+	}
+
+	t.Run("delete and revive", func(t *testing.T) {
+		is := is.New(t)
+		underTest, wClient := setupTest(t, ctx, cfg)
+		gomock.InOrder(
+			wClient.EXPECT().Merge(gomock.Any(), newEqMatcher(&weaviate.Object{
+				ID:         id1,
+				Class:      "test-class",
+				Properties: map[string]any{"deleted": true, "deletedAt": "2026-01-02T03:04:05Z"},
+			})),
+			// the object was already deleted
+			wClient.EXPECT().Merge(gomock.Any(), gomock.Any()).
+				Return(fmt.Errorf("error merging object: %w", weaviate.ErrNotFound)),
+			// a create replaces the deleted object, also if the record
+			// contains the deletion time read from Weaviate
+			wClient.EXPECT().Upsert(gomock.Any(), newEqMatcher([]*weaviate.Object{{
+				ID:         id1,
+				Class:      "test-class",
+				Properties: map[string]any{"name": "a", "deleted": false},
+			}})),
+			wClient.EXPECT().Update(gomock.Any(), newEqMatcher(&weaviate.Object{
+				ID:         id1,
+				Class:      "test-class",
+				Properties: map[string]any{"name": "b", "deleted": false},
+			})),
+		)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{
+			deleteRecord(id1, nil),
+			deleteRecord(id2, nil),
+			sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id1), opencdc.StructuredData{
+				"name": "a", "deleted": true, "deletedAt": "2026-01-02T03:04:05Z",
+			}),
+			sdk.Util.Source.NewRecordUpdate(nil, nil, opencdc.RawData(id1), nil, opencdc.StructuredData{"name": "b"}),
+		})
+		is.NoErr(err)
+		is.Equal(n, 4)
+	})
+
+	t.Run("version", func(t *testing.T) {
+		is := is.New(t)
+		cfg := maps.Clone(cfg)
+		cfg["versionField"] = "v"
+		cfg["contentHash.enabled"] = "true"
+		underTest, wClient := setupTest(t, ctx, cfg)
+		expectClass(wClient, "_contentHash", "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_contentHash", "_version"}, []string{id1}).Return(nil, nil)
+		// the version and the cleared hash are stored on the deleted object,
+		// older changes are ignored after the delete
+		wClient.EXPECT().Merge(gomock.Any(), newEqMatcher(&weaviate.Object{
+			ID:    id1,
+			Class: "test-class",
+			Properties: map[string]any{
+				"deleted": true, "deletedAt": "2026-01-02T03:04:05Z", "_version": "5", "_contentHash": "",
+			},
+		}))
+
+		n, err := underTest.Write(ctx, []opencdc.Record{
+			deleteRecord(id1, opencdc.StructuredData{"v": 5}),
+			sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id1), opencdc.StructuredData{"v": 4}),
+		})
+		is.NoErr(err)
+		is.Equal(n, 2)
+	})
+
+	t.Run("version of missing object", func(t *testing.T) {
+		is := is.New(t)
+		cfg := maps.Clone(cfg)
+		cfg["versionField"] = "v"
+		cfg["contentHash.enabled"] = "true"
+		underTest, wClient := setupTest(t, ctx, cfg)
+		expectClass(wClient, "_contentHash", "_version")
+		wClient.EXPECT().TextProperties(ctx, "test-class", "", []string{"_contentHash", "_version"}, []string{id1}).Return(nil, nil)
+		// nothing was marked as deleted, so the version of the delete isn't
+		// stored and the create is written
+		gomock.InOrder(
+			wClient.EXPECT().Merge(gomock.Any(), gomock.Any()).
+				Return(fmt.Errorf("error merging object: %w", weaviate.ErrNotFound)),
+			wClient.EXPECT().Upsert(gomock.Any(), gomock.Len(1)),
+		)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{
+			deleteRecord(id1, opencdc.StructuredData{"v": 5}),
+			sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id1), opencdc.StructuredData{"v": 4}),
+		})
+		is.NoErr(err)
+		is.Equal(n, 2)
+	})
+}
+
+func TestDestination_Operations(t *testing.T) {
+	ctx := context.Background()
+	cfg := map[string]string{
+		"endpoint":           "test-endpoint",
+		"scheme":             "https",
+		"auth.mechanism":     "apiKey",
+		"auth.apiKey":        "test-api-key",
+		"class":              "test-class",
+		"moduleHeader.name":  "X-OpenAI-Api-Key",
+		"moduleHeader.value": "test-OpenAI-Api-Key",
+	}
+	const id = "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+
+	t.Run("event history", func(t *testing.T) {
+		is := is.New(t)
+		cfg := maps.Clone(cfg)
+		cfg["operations.update"] = "insert"
+		cfg["operations.delete"] = "insert"
+		cfg["operations.id"] = "keyAndPosition"
+		cfg["operations.property"] = "operation"
+		underTest, wClient := setupTest(t, ctx, cfg)
+
+		eventID := func(position string) string {
+			return uuid.NewSHA1(uuid.NameSpaceOID, []byte(id+"\x00"+position)).String()
+		}
+		gomock.InOrder(
+			wClient.EXPECT().Insert(gomock.Any(), newEqMatcher(&weaviate.Object{
+				ID:         eventID("pos-1"),
+				Class:      "test-class",
+				Properties: map[string]any{"name": "a", "operation": "create"},
+			})),
+			wClient.EXPECT().Insert(gomock.Any(), newEqMatcher(&weaviate.Object{
+				ID:         eventID("pos-2"),
+				Class:      "test-class",
+				Properties: map[string]any{"name": "b", "operation": "update"},
+			})),
+			// deletes are written with the payload before the delete, if any
+			wClient.EXPECT().Insert(gomock.Any(), newEqMatcher(&weaviate.Object{
+				ID:         eventID("pos-3"),
+				Class:      "test-class",
+				Properties: map[string]any{"name": "b", "operation": "delete"},
+			})),
+			wClient.EXPECT().Insert(gomock.Any(), newEqMatcher(&weaviate.Object{
+				ID:         eventID("pos-4"),
+				Class:      "test-class",
+				Properties: map[string]any{"operation": "delete"},
+			})),
+		)
+
+		n, err := underTest.Write(ctx, []opencdc.Record{
+			sdk.Util.Source.NewRecordCreate(opencdc.Position("pos-1"), nil, opencdc.RawData(id), opencdc.StructuredData{"name": "a"}),
+			sdk.Util.Source.NewRecordUpdate(opencdc.Position("pos-2"), nil, opencdc.RawData(id), nil, opencdc.StructuredData{"name": "b"}),
+			sdk.Util.Source.NewRecordDelete(opencdc.Position("pos-3"), nil, opencdc.RawData(id), opencdc.StructuredData{"name": "b"}),
+			sdk.Util.Source.NewRecordDelete(opencdc.Position("pos-4"), nil, opencdc.RawData(id), nil),
+		})
+		is.NoErr(err)
+		is.Equal(n, 4)
+	})
+
+	t.Run("ignore and update", func(t *testing.T) {
+		is := is.New(t)
+		cfg := maps.Clone(cfg)
+		cfg["operations.create"] = "ignore"
+		cfg["operations.delete"] = "ignore"
+		cfg["operations.snapshot"] = "update"
+		underTest, wClient := setupTest(t, ctx, cfg)
+		wClient.EXPECT().Update(gomock.Any(), newEqMatcher(&weaviate.Object{
+			ID:         id,
+			Class:      "test-class",
+			Properties: map[string]any{"name": "a"},
+		}))
+
+		n, err := underTest.Write(ctx, []opencdc.Record{
+			sdk.Util.Source.NewRecordCreate(nil, nil, opencdc.RawData(id), opencdc.StructuredData{"name": "a"}),
+			sdk.Util.Source.NewRecordSnapshot(nil, nil, opencdc.RawData(id), opencdc.StructuredData{"name": "a"}),
+			sdk.Util.Source.NewRecordDelete(nil, nil, opencdc.RawData(id), nil),
+		})
+		is.NoErr(err)
+		is.Equal(n, 3)
+	})
+}
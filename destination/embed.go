@@ -0,0 +1,234 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/destination/embedding"
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+const (
+	ProviderNone    = "none"
+	ProviderOpenAI  = "openai"
+	ProviderHashing = "hashing"
+)
+
+// vectorsKey is the context key of the vectors computed by embedRecords.
+type vectorsKey struct{}
+
+// newEmbedder returns the configured embedder, or nil if no provider is
+// configured. If the cache is enabled, the embedder is wrapped by it.
+func (d *Destination) newEmbedder() (embedding.Embedder, error) {
+	cfg := d.config.Embedding
+
+	var (
+		embedder  embedding.Embedder
+		namespace string
+	)
+	switch cfg.Provider {
+	case ProviderOpenAI:
+		embedder = embedding.NewOpenAI(embedding.OpenAIConfig{
+			BaseURL:    cfg.OpenAI.BaseURL,
+			APIKey:     cfg.OpenAI.APIKey,
+			Model:      cfg.OpenAI.Model,
+			Dimensions: cfg.Dimensions,
+			BatchSize:  cfg.BatchSize,
+			Timeout:    cfg.OpenAI.Timeout,
+		})
+		namespace = fmt.Sprintf("%v/%v/%v/%v", cfg.Provider, cfg.OpenAI.BaseURL, cfg.OpenAI.Model, cfg.Dimensions)
+	case ProviderHashing:
+		embedder = embedding.NewHashing(cfg.Dimensions)
+		namespace = fmt.Sprintf("%v/%v", cfg.Provider, cfg.Dimensions)
+	default:
+		return nil, nil
+	}
+
+	if !cfg.Cache.Enabled {
+		return embedder, nil
+	}
+	cache, err := embedding.NewCache(cfg.Cache.Path, namespace, cfg.Cache.MaxEntries, embedder)
+	if err != nil {
+		return nil, err
+	}
+	d.cache = cache
+
+	return cache, nil
+}
+
+// logCacheStats logs the hits and misses of the embedding cache during a
+// Write, given the totals when the Write started, and the totals since the
+// cache was opened.
+func (d *Destination) logCacheStats(ctx context.Context, hits, misses uint64) {
+	totalHits, totalMisses := d.cache.Stats()
+	sdk.Logger(ctx).Debug().
+		Uint64("hits", totalHits-hits).
+		Uint64("misses", totalMisses-misses).
+		Uint64("totalHits", totalHits).
+		Uint64("totalMisses", totalMisses).
+		Msg("embedding cache stats")
+}
+
+// embedRecords embeds the texts of all objects written for the records at
+// once, so that they are sent to the provider in as few requests as possible,
+// and returns a context with the vectors, which are used by embed. If that
+// fails, the objects are embedded when they are written.
+func (d *Destination) embedRecords(ctx context.Context, records []opencdc.Record) context.Context {
+	var texts []string
+	for _, record := range records {
+		if op := d.operation(record); op == OperationDelete || op == OperationIgnore {
+			continue
+		}
+
+		// records that can't be converted fail when they are written
+		obj, err := d.toWeaviateObj(record)
+		if err != nil {
+			continue
+		}
+		if skip, err := d.skip(ctx, record, obj); err != nil || skip {
+			continue
+		}
+		objects := []*weaviate.Object{obj}
+		if d.config.Chunking.Enabled {
+			objects, err = d.chunkObjects(obj)
+			if err != nil {
+				continue
+			}
+		}
+
+		for _, o := range objects {
+			text, err := d.embeddingText(o)
+			if err == nil && text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+	if len(texts) == 0 {
+		return ctx
+	}
+
+	vectors, err := d.embedTexts(ctx, texts)
+	if err != nil {
+		sdk.Logger(ctx).Warn().Err(err).Msg("error embedding texts of records, embedding them one record at a time")
+		return ctx
+	}
+
+	return context.WithValue(ctx, vectorsKey{}, vectors)
+}
+
+// embed sets the configured vector of the objects to the embedding of their
+// text. Vectors computed by embedRecords are reused, the others are computed
+// with a single request.
+func (d *Destination) embed(ctx context.Context, objects []*weaviate.Object) error {
+	if d.embedder == nil {
+		return nil
+	}
+
+	texts := make([]string, len(objects))
+	var missing []string
+	vectors, _ := ctx.Value(vectorsKey{}).(map[string][]float32)
+	for i, obj := range objects {
+		text, err := d.embeddingText(obj)
+		if err != nil {
+			return err
+		}
+		texts[i] = text
+		if text != "" && vectors[text] == nil {
+			missing = append(missing, text)
+		}
+	}
+
+	if len(missing) > 0 {
+		computed, err := d.embedTexts(ctx, missing)
+		if err != nil {
+			return err
+		}
+		for text, v := range vectors {
+			computed[text] = v
+		}
+		vectors = computed
+	}
+
+	for i, obj := range objects {
+		if texts[i] == "" {
+			continue
+		}
+		if d.config.Embedding.Vector == "" {
+			obj.Vector = vectors[texts[i]]
+			continue
+		}
+		if obj.Vectors == nil {
+			obj.Vectors = make(map[string][]float32)
+		}
+		obj.Vectors[d.config.Embedding.Vector] = vectors[texts[i]]
+	}
+
+	return nil
+}
+
+// embeddingText returns the text of the object that is embedded, which is
+// empty if the object has no text or already has the vector.
+func (d *Destination) embeddingText(obj *weaviate.Object) (string, error) {
+	cfg := d.config.Embedding
+	if cfg.Vector == "" && len(obj.Vector) > 0 || cfg.Vector != "" && len(obj.Vectors[cfg.Vector]) > 0 {
+		return "", nil
+	}
+
+	var parts []string
+	for _, name := range cfg.Properties {
+		switch v := obj.Properties[name].(type) {
+		case string:
+			if v != "" {
+				parts = append(parts, v)
+			}
+		case nil:
+		default:
+			return "", fmt.Errorf("property %v is a %T, not text", name, v)
+		}
+	}
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// embedTexts returns the vectors of the texts, keyed by text. Texts are only
+// embedded once.
+func (d *Destination) embedTexts(ctx context.Context, texts []string) (map[string][]float32, error) {
+	vectors := make(map[string][]float32, len(texts))
+	var unique []string
+	for _, text := range texts {
+		if _, ok := vectors[text]; !ok {
+			vectors[text] = nil
+			unique = append(unique, text)
+		}
+	}
+
+	embeddings, err := d.embedder.Embed(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("error embedding texts: %w", err)
+	}
+	if len(embeddings) != len(unique) {
+		return nil, fmt.Errorf("error embedding texts: got %v vectors for %v texts", len(embeddings), len(unique))
+	}
+	for i, text := range unique {
+		vectors[text] = embeddings[i]
+	}
+
+	return vectors, nil
+}
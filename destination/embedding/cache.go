@@ -0,0 +1,218 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	vectorsBucket = []byte("vectors")
+	orderBucket   = []byte("order")
+)
+
+// Cache is an Embedder returning the vectors of texts embedded before from a
+// BoltDB file, and embedding the other texts with the wrapped Embedder. The
+// cache holds at most maxEntries vectors, the oldest vectors are evicted
+// first.
+type Cache struct {
+	db         *bolt.DB
+	next       Embedder
+	namespace  string
+	maxEntries int
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewCache opens or creates the cache file at path. The namespace identifies
+// the vectors of next, e.g. by the provider, model and dimensions, so that
+// vectors of another model are never returned.
+func NewCache(path, namespace string, maxEntries int, next Embedder) (*Cache, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening cache %v: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{vectorsBucket, orderBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error creating cache buckets: %w", err)
+	}
+
+	return &Cache{
+		db:         db,
+		next:       next,
+		namespace:  namespace,
+		maxEntries: maxEntries,
+	}, nil
+}
+
+// Embed returns the cached vectors of the texts, and embeds and caches the
+// vectors of the others.
+func (c *Cache) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	keys := make([][]byte, len(texts))
+	for i, text := range texts {
+		keys[i] = c.key(text)
+	}
+
+	vectors := make([][]float32, len(texts))
+	var missing []int
+	err := c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(vectorsBucket)
+		for i, key := range keys {
+			if v := b.Get(key); v != nil {
+				vectors[i] = decodeVector(v)
+			} else {
+				missing = append(missing, i)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading cache: %w", err)
+	}
+	c.hits.Add(uint64(len(texts) - len(missing)))
+	c.misses.Add(uint64(len(missing)))
+	if len(missing) == 0 {
+		return vectors, nil
+	}
+
+	missingTexts := make([]string, len(missing))
+	for i, idx := range missing {
+		missingTexts[i] = texts[idx]
+	}
+	embedded, err := c.next.Embed(ctx, missingTexts)
+	if err != nil {
+		return nil, err
+	}
+	if len(embedded) != len(missing) {
+		return nil, fmt.Errorf("got %v vectors for %v texts", len(embedded), len(missing))
+	}
+
+	for i, idx := range missing {
+		vectors[idx] = embedded[i]
+	}
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		for i, idx := range missing {
+			if err := c.put(tx, keys[idx], embedded[i]); err != nil {
+				return err
+			}
+		}
+		return c.evict(tx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error writing cache: %w", err)
+	}
+
+	return vectors, nil
+}
+
+// Stats returns the number of texts found in the cache and the number of
+// texts that had to be embedded since the cache was opened.
+func (c *Cache) Stats() (hits, misses uint64) {
+	return c.hits.Load(), c.misses.Load()
+}
+
+func (c *Cache) Close() error {
+	return c.db.Close()
+}
+
+// key returns the SHA-256 hash of the namespace and the text.
+func (c *Cache) key(text string) []byte {
+	h := sha256.New()
+	h.Write([]byte(c.namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(text))
+	return h.Sum(nil)
+}
+
+// put stores the vector, and records the order it was stored in, unless the
+// key is already cached.
+func (c *Cache) put(tx *bolt.Tx, key []byte, vector []float32) error {
+	vectors := tx.Bucket(vectorsBucket)
+	if vectors.Get(key) != nil {
+		return nil
+	}
+
+	order := tx.Bucket(orderBucket)
+	seq, err := order.NextSequence()
+	if err != nil {
+		return err
+	}
+	err = order.Put(binary.BigEndian.AppendUint64(nil, seq), key)
+	if err != nil {
+		return err
+	}
+
+	return vectors.Put(key, encodeVector(vector))
+}
+
+// evict deletes the oldest vectors exceeding maxEntries. As vectors are
+// only deleted in the order they were stored in, the sequence numbers of the
+// cached vectors are contiguous.
+func (c *Cache) evict(tx *bolt.Tx) error {
+	vectors := tx.Bucket(vectorsBucket)
+	order := tx.Bucket(orderBucket)
+
+	cursor := order.Cursor()
+	k, key := cursor.First()
+	if k == nil {
+		return nil
+	}
+	excess := int(order.Sequence()-binary.BigEndian.Uint64(k)+1) - c.maxEntries //nolint:gosec // the number of entries fits into an int
+	for ; k != nil && excess > 0; k, key = cursor.First() {
+		if err := vectors.Delete(key); err != nil {
+			return err
+		}
+		if err := cursor.Delete(); err != nil {
+			return err
+		}
+		excess--
+	}
+
+	return nil
+}
+
+func encodeVector(vector []float32) []byte {
+	data := make([]byte, 0, 4*len(vector))
+	for _, v := range vector {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(v))
+	}
+	return data
+}
+
+func decodeVector(data []byte) []float32 {
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return vector
+}
@@ -0,0 +1,32 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embedding computes vectors of texts on the client side, before
+// objects are written to Weaviate.
+package embedding
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRateLimited is returned when the embedding provider responds that the
+// rate limit has been reached.
+var ErrRateLimited = errors.New("rate limit reached")
+
+// Embedder computes vectors of texts.
+type Embedder interface {
+	// Embed returns the vectors of the texts, in the same order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
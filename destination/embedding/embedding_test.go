@@ -0,0 +1,195 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/destination/embedding"
+	"github.com/matryer/is"
+)
+
+// openAIServer is a stand-in for an OpenAI compatible embeddings API, which
+// embeds a text as a vector of its length, and returns the embeddings in
+// reverse order.
+type openAIServer struct {
+	mu       sync.Mutex
+	requests []map[string]any
+	status   int
+}
+
+func (s *openAIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer test-key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+		return
+	}
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(`{"error":{"message":"slow down"}}`))
+		return
+	}
+
+	var req map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	s.requests = append(s.requests, req)
+
+	input := req["input"].([]any)
+	data := make([]map[string]any, 0, len(input))
+	for i := len(input) - 1; i >= 0; i-- {
+		data = append(data, map[string]any{
+			"index":     i,
+			"embedding": []float32{float32(len(input[i].(string)))},
+		})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func TestOpenAI_Embed(t *testing.T) {
+	is := is.New(t)
+	srv := &openAIServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	underTest := embedding.NewOpenAI(embedding.OpenAIConfig{
+		BaseURL:    ts.URL + "/v1/",
+		APIKey:     "test-key",
+		Model:      "test-model",
+		Dimensions: 1,
+		BatchSize:  2,
+	})
+
+	vectors, err := underTest.Embed(context.Background(), []string{"a", "bb", "ccc"})
+	is.NoErr(err)
+	is.Equal(vectors, [][]float32{{1}, {2}, {3}})
+
+	is.Equal(len(srv.requests), 2)
+	is.Equal(srv.requests[0], map[string]any{"model": "test-model", "input": []any{"a", "bb"}, "dimensions": float64(1)})
+	is.Equal(srv.requests[1], map[string]any{"model": "test-model", "input": []any{"ccc"}, "dimensions": float64(1)})
+}
+
+func TestOpenAI_Embed_Errors(t *testing.T) {
+	srv := &openAIServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	t.Run("rate limited", func(t *testing.T) {
+		is := is.New(t)
+		srv.status = http.StatusTooManyRequests
+		defer func() { srv.status = 0 }()
+
+		underTest := embedding.NewOpenAI(embedding.OpenAIConfig{BaseURL: ts.URL + "/v1", APIKey: "test-key"})
+		_, err := underTest.Embed(context.Background(), []string{"a"})
+		is.True(errors.Is(err, embedding.ErrRateLimited))
+		is.True(strings.Contains(err.Error(), "slow down"))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		is := is.New(t)
+		underTest := embedding.NewOpenAI(embedding.OpenAIConfig{BaseURL: ts.URL + "/v1", APIKey: "wrong-key"})
+		_, err := underTest.Embed(context.Background(), []string{"a"})
+		is.True(err != nil)
+		is.True(!errors.Is(err, embedding.ErrRateLimited))
+		is.True(strings.Contains(err.Error(), "status 401: invalid api key"))
+	})
+}
+
+func TestHashing_Embed(t *testing.T) {
+	is := is.New(t)
+	underTest := embedding.NewHashing(16)
+
+	vectors, err := underTest.Embed(context.Background(), []string{"The quick fox", "the QUICK   fox", "", "something else"})
+	is.NoErr(err)
+	is.Equal(len(vectors), 4)
+	is.Equal(vectors[0], vectors[1])
+	is.Equal(vectors[2], make([]float32, 16))
+	is.True(len(vectors[3]) == 16)
+
+	var norm float64
+	for _, v := range vectors[0] {
+		norm += float64(v * v)
+	}
+	is.True(math.Abs(norm-1) < 1e-6)
+}
+
+// countingEmbedder embeds texts with a hashing embedder and records them.
+type countingEmbedder struct {
+	embedded []string
+}
+
+func (e *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.embedded = append(e.embedded, texts...)
+	return embedding.NewHashing(4).Embed(ctx, texts)
+}
+
+func TestCache_Embed(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.db")
+	want, err := embedding.NewHashing(4).Embed(ctx, []string{"a", "b", "c"})
+	is.NoErr(err)
+
+	next := &countingEmbedder{}
+	underTest, err := embedding.NewCache(path, "test", 2, next)
+	is.NoErr(err)
+
+	got, err := underTest.Embed(ctx, []string{"a", "b"})
+	is.NoErr(err)
+	is.Equal(got, want[:2])
+	got, err = underTest.Embed(ctx, []string{"b", "a"})
+	is.NoErr(err)
+	is.Equal(got, [][]float32{want[1], want[0]})
+	is.Equal(next.embedded, []string{"a", "b"})
+
+	hits, misses := underTest.Stats()
+	is.Equal(hits, uint64(2))
+	is.Equal(misses, uint64(2))
+
+	// caching c evicts a, which was cached first
+	got, err = underTest.Embed(ctx, []string{"c"})
+	is.NoErr(err)
+	is.Equal(got, want[2:])
+	is.NoErr(underTest.Close())
+
+	// the cache persists, the vectors of other namespaces aren't returned
+	next = &countingEmbedder{}
+	underTest, err = embedding.NewCache(path, "test", 2, next)
+	is.NoErr(err)
+	got, err = underTest.Embed(ctx, []string{"a", "b", "c"})
+	is.NoErr(err)
+	is.Equal(got, want)
+	is.Equal(next.embedded, []string{"a"})
+	is.NoErr(underTest.Close())
+
+	next = &countingEmbedder{}
+	underTest, err = embedding.NewCache(path, "other", 2, next)
+	is.NoErr(err)
+	_, err = underTest.Embed(ctx, []string{"b"})
+	is.NoErr(err)
+	is.Equal(next.embedded, []string{"b"})
+	is.NoErr(underTest.Close())
+}
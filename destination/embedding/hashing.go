@@ -0,0 +1,73 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+// Hashing is an Embedder computing vectors by hashing the words of a text
+// into the dimensions of the vector. The vectors are deterministic and texts
+// sharing words have similar vectors, which makes it useful for testing, but
+// they don't capture the meaning of the texts.
+type Hashing struct {
+	dimensions int
+}
+
+func NewHashing(dimensions int) *Hashing {
+	return &Hashing{dimensions: dimensions}
+}
+
+func (h *Hashing) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = h.embed(text)
+	}
+	return vectors, nil
+}
+
+// embed adds 1 or -1 to the dimension each lowercased word hashes to, and
+// normalizes the vector to unit length.
+func (h *Hashing) embed(text string) []float32 {
+	vector := make([]float32, h.dimensions)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(word))
+		sum := hash.Sum64()
+
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		vector[sum%uint64(h.dimensions)] += sign //nolint:gosec // the number of dimensions is always positive
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v * v)
+	}
+	if norm == 0 {
+		return vector
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / norm)
+	}
+
+	return vector
+}
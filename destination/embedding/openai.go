@@ -0,0 +1,170 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultOpenAITimeout = 60 * time.Second
+
+// OpenAIConfig configures an OpenAI compatible embeddings API.
+type OpenAIConfig struct {
+	// BaseURL is the URL of the API, e.g. `https://api.openai.com/v1`. The
+	// embeddings are requested from `<BaseURL>/embeddings`.
+	BaseURL string
+	// APIKey is sent as a bearer token, if not empty.
+	APIKey string
+	// Model is the name of the embedding model.
+	Model string
+	// Dimensions is the number of dimensions of the vectors, for models
+	// supporting shortened vectors. Zero means the model's default.
+	Dimensions int
+	// BatchSize is the maximum number of texts embedded with one request.
+	// Zero means all texts are sent with one request.
+	BatchSize int
+	// Timeout limits the time of a request. Zero means a minute.
+	Timeout time.Duration
+}
+
+// OpenAI is an Embedder using an OpenAI compatible embeddings API.
+type OpenAI struct {
+	config OpenAIConfig
+	client *http.Client
+}
+
+func NewOpenAI(config OpenAIConfig) *OpenAI {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultOpenAITimeout
+	}
+
+	return &OpenAI{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type openAIRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openAIResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+type openAIError struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Embed returns the vectors of the texts, requesting them in batches of
+// BatchSize texts.
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	batchSize := o.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		batch := texts[start:min(start+batchSize, len(texts))]
+		v, err := o.embed(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, v...)
+	}
+
+	return vectors, nil
+}
+
+func (o *OpenAI) embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIRequest{
+		Model:      o.config.Model,
+		Input:      texts,
+		Dimensions: o.config.Dimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+
+	url := strings.TrimSuffix(o.config.BaseURL, "/") + "/embeddings"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.config.APIKey)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting embeddings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(data))
+		var apiErr openAIError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			msg = apiErr.Error.Message
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("%w: %v", ErrRateLimited, msg)
+		}
+		return nil, fmt.Errorf("error requesting embeddings: status %v: %v", resp.StatusCode, msg)
+	}
+
+	var r openAIResponse
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range r.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("invalid response: unexpected index %v", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("invalid response: missing embedding %v", i)
+		}
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,188 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"golang.org/x/net/http/httpguts"
+)
+
+type Providers struct {
+	OpenAI      OpenAIProvider      `json:"openai"`
+	AzureOpenAI AzureOpenAIProvider `json:"azureOpenai"`
+	Cohere      CohereProvider      `json:"cohere"`
+	HuggingFace APIKeyProvider      `json:"huggingface"`
+	VoyageAI    APIKeyProvider      `json:"voyageai"`
+	Jina        APIKeyProvider      `json:"jina"`
+}
+
+type OpenAIProvider struct {
+	// OpenAI API key, sent as `X-OpenAI-Api-Key`.
+	APIKey string `json:"apiKey"`
+	// OpenAI organization, sent as `X-OpenAI-Organization`.
+	Organization string `json:"organization"`
+	// URL of an OpenAI compatible API, sent as `X-OpenAI-BaseURL`.
+	BaseURL string `json:"baseUrl"`
+}
+
+type AzureOpenAIProvider struct {
+	// Azure OpenAI API key, sent as `X-Azure-Api-Key`.
+	APIKey string `json:"apiKey"`
+	// Azure OpenAI resource name, sent as `X-Azure-Resource-Name`.
+	ResourceName string `json:"resourceName"`
+	// Azure OpenAI deployment ID, sent as `X-Azure-Deployment-Id`.
+	DeploymentID string `json:"deploymentId"`
+}
+
+type CohereProvider struct {
+	// Cohere API key, sent as `X-Cohere-Api-Key`.
+	APIKey string `json:"apiKey"`
+	// URL of the Cohere API, sent as `X-Cohere-BaseURL`.
+	BaseURL string `json:"baseUrl"`
+}
+
+type APIKeyProvider struct {
+	// API key of the provider, sent as `X-<Provider>-Api-Key`.
+	APIKey string `json:"apiKey"`
+}
+
+// providerHeader describes a header set from a provider section.
+type providerHeader struct {
+	param  string
+	header string
+	value  string
+}
+
+// headers returns the headers configured by the provider sections, grouped
+// by provider. The first header of each provider is its API key.
+func (p Providers) headers() [][]providerHeader {
+	return [][]providerHeader{
+		{
+			{"providers.openai.apiKey", "X-OpenAI-Api-Key", p.OpenAI.APIKey},
+			{"providers.openai.organization", "X-OpenAI-Organization", p.OpenAI.Organization},
+			{"providers.openai.baseUrl", "X-OpenAI-BaseURL", p.OpenAI.BaseURL},
+		},
+		{
+			{"providers.azureOpenai.apiKey", "X-Azure-Api-Key", p.AzureOpenAI.APIKey},
+			{"providers.azureOpenai.resourceName", "X-Azure-Resource-Name", p.AzureOpenAI.ResourceName},
+			{"providers.azureOpenai.deploymentId", "X-Azure-Deployment-Id", p.AzureOpenAI.DeploymentID},
+		},
+		{
+			{"providers.cohere.apiKey", "X-Cohere-Api-Key", p.Cohere.APIKey},
+			{"providers.cohere.baseUrl", "X-Cohere-BaseURL", p.Cohere.BaseURL},
+		},
+		{{"providers.huggingface.apiKey", "X-HuggingFace-Api-Key", p.HuggingFace.APIKey}},
+		{{"providers.voyageai.apiKey", "X-VoyageAI-Api-Key", p.VoyageAI.APIKey}},
+		{{"providers.jina.apiKey", "X-JinaAI-Api-Key", p.Jina.APIKey}},
+	}
+}
+
+// ModuleHeaders returns the headers configuring Weaviate modules, combined
+// from `moduleHeader`, `headers` and `providers`. An error is returned if a
+// header is invalid or set more than once with different values.
+func (c *Config) ModuleHeaders() (map[string]string, error) {
+	headers := make(map[string]string)
+	// params contains the parameter and name each header was set with,
+	// keyed by the canonical header name
+	params := make(map[string][2]string)
+
+	add := func(param, name, value string) error {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("%v: invalid header name %q", param, name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("%v: invalid value for header %v", param, name)
+		}
+
+		key := http.CanonicalHeaderKey(name)
+		if prev, ok := params[key]; ok {
+			if headers[prev[1]] != value {
+				return fmt.Errorf("header %v is set by both %v and %v with different values", name, prev[0], param)
+			}
+			return nil
+		}
+		params[key] = [2]string{param, name}
+		headers[name] = value
+		return nil
+	}
+
+	var errs []error
+	if c.ModuleHeader.Name != "" {
+		errs = append(errs, add("moduleHeader.name", c.ModuleHeader.Name, c.ModuleHeader.Value))
+	}
+	for name, value := range c.Headers {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("headers.%v: value is empty", name))
+			continue
+		}
+		errs = append(errs, add("headers."+name, name, value))
+	}
+	for _, provider := range c.Providers.headers() {
+		apiKey := provider[0]
+		for _, h := range provider {
+			if h.value == "" {
+				continue
+			}
+			if apiKey.value == "" {
+				errs = append(errs, fmt.Errorf("%v requires %v", h.param, apiKey.param))
+				break
+			}
+			errs = append(errs, add(h.param, h.header, h.value))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+// recordHeaders returns the headers the record is written with, taken from
+// the secrets named in its `weaviate.header.*` metadata fields. The values
+// of the secrets are never included in errors.
+func (d *Destination) recordHeaders(record opencdc.Record) (map[string]string, error) {
+	var headers map[string]string
+	for key, secret := range record.Metadata {
+		name, ok := strings.CutPrefix(key, MetadataHeaderPrefix)
+		if !ok {
+			continue
+		}
+
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("metadata field %v: invalid header name %q", key, name)
+		}
+		if http.CanonicalHeaderKey(name) == "Authorization" {
+			return nil, fmt.Errorf("metadata field %v: the Authorization header can't be set per record", key)
+		}
+
+		value, ok := d.config.Secrets[secret]
+		if !ok {
+			return nil, fmt.Errorf("metadata field %v references unknown secret %q", key, secret)
+		}
+
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[name] = value
+	}
+
+	return headers, nil
+}
@@ -13,7 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
-	weaviate "github.com/conduitio-labs/conduit-connector-weaviate/destination/weaviate"
+	weaviate "github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	filters "github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,64 @@ func (m *WeaviateClient) EXPECT() *WeaviateClientMockRecorder {
 	return m.recorder
 }
 
+// Class mocks base method.
+func (m *WeaviateClient) Class(ctx context.Context, class string) (*weaviate.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Class", ctx, class)
+	ret0, _ := ret[0].(*weaviate.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Class indicates an expected call of Class.
+func (mr *WeaviateClientMockRecorder) Class(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Class", reflect.TypeOf((*WeaviateClient)(nil).Class), ctx, class)
+}
+
+// ClassExists mocks base method.
+func (m *WeaviateClient) ClassExists(ctx context.Context, class string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClassExists", ctx, class)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClassExists indicates an expected call of ClassExists.
+func (mr *WeaviateClientMockRecorder) ClassExists(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClassExists", reflect.TypeOf((*WeaviateClient)(nil).ClassExists), ctx, class)
+}
+
+// Close mocks base method.
+func (m *WeaviateClient) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *WeaviateClientMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*WeaviateClient)(nil).Close))
+}
+
+// CreateClass mocks base method.
+func (m *WeaviateClient) CreateClass(ctx context.Context, class string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClass", ctx, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateClass indicates an expected call of CreateClass.
+func (mr *WeaviateClientMockRecorder) CreateClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClass", reflect.TypeOf((*WeaviateClient)(nil).CreateClass), ctx, class)
+}
+
 // Delete mocks base method.
 func (m *WeaviateClient) Delete(arg0 context.Context, arg1 *weaviate.Object) error {
 	m.ctrl.T.Helper()
@@ -55,6 +114,20 @@ func (mr *WeaviateClientMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*WeaviateClient)(nil).Delete), arg0, arg1)
 }
 
+// DeleteWhere mocks base method.
+func (m *WeaviateClient) DeleteWhere(ctx context.Context, class, tenant string, where *filters.WhereBuilder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWhere", ctx, class, tenant, where)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWhere indicates an expected call of DeleteWhere.
+func (mr *WeaviateClientMockRecorder) DeleteWhere(ctx, class, tenant, where any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWhere", reflect.TypeOf((*WeaviateClient)(nil).DeleteWhere), ctx, class, tenant, where)
+}
+
 // Insert mocks base method.
 func (m *WeaviateClient) Insert(arg0 context.Context, arg1 *weaviate.Object) error {
 	m.ctrl.T.Helper()
@@ -69,6 +142,50 @@ func (mr *WeaviateClientMockRecorder) Insert(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*WeaviateClient)(nil).Insert), arg0, arg1)
 }
 
+// Live mocks base method.
+func (m *WeaviateClient) Live(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Live", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Live indicates an expected call of Live.
+func (mr *WeaviateClientMockRecorder) Live(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Live", reflect.TypeOf((*WeaviateClient)(nil).Live), arg0)
+}
+
+// Merge mocks base method.
+func (m *WeaviateClient) Merge(arg0 context.Context, arg1 *weaviate.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *WeaviateClientMockRecorder) Merge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*WeaviateClient)(nil).Merge), arg0, arg1)
+}
+
+// Meta mocks base method.
+func (m *WeaviateClient) Meta(arg0 context.Context) (*weaviate.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Meta", arg0)
+	ret0, _ := ret[0].(*weaviate.Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Meta indicates an expected call of Meta.
+func (mr *WeaviateClientMockRecorder) Meta(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Meta", reflect.TypeOf((*WeaviateClient)(nil).Meta), arg0)
+}
+
 // Open mocks base method.
 func (m *WeaviateClient) Open(arg0 weaviate.Config) error {
 	m.ctrl.T.Helper()
@@ -83,6 +200,36 @@ func (mr *WeaviateClientMockRecorder) Open(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*WeaviateClient)(nil).Open), arg0)
 }
 
+// Ready mocks base method.
+func (m *WeaviateClient) Ready(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *WeaviateClientMockRecorder) Ready(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*WeaviateClient)(nil).Ready), arg0)
+}
+
+// TextProperties mocks base method.
+func (m *WeaviateClient) TextProperties(ctx context.Context, class, tenant string, properties, ids []string) (map[string]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TextProperties", ctx, class, tenant, properties, ids)
+	ret0, _ := ret[0].(map[string]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TextProperties indicates an expected call of TextProperties.
+func (mr *WeaviateClientMockRecorder) TextProperties(ctx, class, tenant, properties, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TextProperties", reflect.TypeOf((*WeaviateClient)(nil).TextProperties), ctx, class, tenant, properties, ids)
+}
+
 // Update mocks base method.
 func (m *WeaviateClient) Update(arg0 context.Context, arg1 *weaviate.Object) error {
 	m.ctrl.T.Helper()
@@ -96,3 +243,17 @@ func (mr *WeaviateClientMockRecorder) Update(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*WeaviateClient)(nil).Update), arg0, arg1)
 }
+
+// Upsert mocks base method.
+func (m *WeaviateClient) Upsert(arg0 context.Context, arg1 []*weaviate.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *WeaviateClientMockRecorder) Upsert(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*WeaviateClient)(nil).Upsert), arg0, arg1)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	OperationInsert = "insert"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationIgnore = "ignore"
+
+	IDKey            = "key"
+	IDKeyAndPosition = "keyAndPosition"
+)
+
+// handler returns the function writing records with the configured
+// operation.
+func (d *Destination) handler(operation string) func(context.Context, opencdc.Record) error {
+	switch operation {
+	case OperationUpdate:
+		return d.update
+	case OperationDelete:
+		return d.delete
+	case OperationIgnore:
+		return func(context.Context, opencdc.Record) error { return nil }
+	default:
+		return d.insert
+	}
+}
+
+// operation returns the configured operation the record is written with.
+func (d *Destination) operation(record opencdc.Record) string {
+	ops := d.config.Operations
+	switch record.Operation {
+	case opencdc.OperationCreate:
+		return ops.Create
+	case opencdc.OperationUpdate:
+		return ops.Update
+	case opencdc.OperationDelete:
+		return ops.Delete
+	default:
+		return ops.Snapshot
+	}
+}
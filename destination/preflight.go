@@ -0,0 +1,230 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Range of supported Weaviate versions, the maximum version is exclusive.
+var (
+	minServerVersion = [3]int{1, 21, 0}
+	maxServerVersion = [3]int{2, 0, 0}
+)
+
+// moduleHeaderPrefixes maps prefixes of module headers to the parts of the
+// module names the header configures, e.g. `X-OpenAI-Api-Key` is used by the
+// `text2vec-openai` and `generative-openai` modules. The Google modules were
+// called `palm` in older Weaviate versions, and accept both headers.
+var moduleHeaderPrefixes = map[string][]string{
+	"X-OpenAI-":      {"openai"},
+	"X-Azure-":       {"openai"},
+	"X-Cohere-":      {"cohere"},
+	"X-HuggingFace-": {"huggingface"},
+	"X-VoyageAI-":    {"voyageai"},
+	"X-JinaAI-":      {"jinaai"},
+	"X-Palm-":        {"google", "palm"},
+	"X-Google-":      {"google", "palm"},
+}
+
+// preflight checks that the Weaviate instance can be used with the
+// configuration. An error is returned for every failed check. If the instance
+// can't be reached or the credentials are rejected, the remaining checks are
+// skipped, since they would fail for the same reason.
+func (d *Destination) preflight(ctx context.Context) error {
+	live, err := d.client.Live(ctx)
+	if err != nil || !live {
+		return fmt.Errorf(
+			"could not reach Weaviate at %v, check `endpoint` and `scheme`: %w",
+			d.weaviateURL(), errOrFalse(err),
+		)
+	}
+
+	ready, err := d.client.Ready(ctx)
+	if err != nil || !ready {
+		return fmt.Errorf("the Weaviate instance is live, but not ready to serve requests: %w", errOrFalse(err))
+	}
+
+	meta, err := d.client.Meta(ctx)
+	if errors.Is(err, weaviate.ErrUnauthorized) {
+		return fmt.Errorf("the credentials were rejected by Weaviate, check the `auth.*` parameters: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed getting Weaviate metadata: %w", err)
+	}
+
+	var errs []error
+	if err := checkServerVersion(meta.Version); err != nil {
+		errs = append(errs, err)
+	}
+	if err := d.checkModules(meta.Modules); err != nil {
+		errs = append(errs, err)
+	}
+	if err := d.checkClass(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func checkServerVersion(version string) error {
+	v, err := parseVersion(version)
+	if err != nil {
+		return fmt.Errorf("unsupported Weaviate version %q: %w", version, err)
+	}
+
+	if compareVersions(v, minServerVersion) < 0 || compareVersions(v, maxServerVersion) >= 0 {
+		return fmt.Errorf(
+			"unsupported Weaviate version %v, supported versions are >= %v and < %v",
+			version, formatVersion(minServerVersion), formatVersion(maxServerVersion),
+		)
+	}
+
+	return nil
+}
+
+// checkModules checks that the modules configured by the module headers
+// are enabled.
+func (d *Destination) checkModules(modules []string) error {
+	headers, err := d.config.ModuleHeaders()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	reported := make(map[string]bool)
+	for _, name := range names {
+		parts := headerModule(name)
+		want := strings.Join(parts, " or ")
+		if want == "" || reported[want] || slices.ContainsFunc(modules, func(m string) bool {
+			return slices.ContainsFunc(parts, func(part string) bool {
+				return strings.Contains(m, part)
+			})
+		}) {
+			continue
+		}
+		reported[want] = true
+		errs = append(errs, fmt.Errorf(
+			"header %v configures a %v module, but no such module is enabled in Weaviate (enabled modules: %v)",
+			name, want, strings.Join(modules, ", "),
+		))
+	}
+
+	return errors.Join(errs...)
+}
+
+// headerModule returns the parts of the module names configured by the
+// header, or nil if the header is unknown.
+func headerModule(name string) []string {
+	for prefix, module := range moduleHeaderPrefixes {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			return module
+		}
+	}
+	return nil
+}
+
+// checkClass checks that the class exists, and creates it if
+// `preflight.createClass` is set. Otherwise, a missing class is only logged,
+// since auto-schema can create it on the first write.
+func (d *Destination) checkClass(ctx context.Context) error {
+	exists, err := d.client.ClassExists(ctx, d.config.Class)
+	if err != nil {
+		return fmt.Errorf("failed checking if class %v exists: %w", d.config.Class, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if !d.config.Preflight.CreateClass {
+		sdk.Logger(ctx).Warn().
+			Str("class", d.config.Class).
+			Msg("class does not exist, it will be created by auto-schema on the first write if auto-schema is enabled, " +
+				"set `preflight.createClass` to true to create it now")
+		return nil
+	}
+
+	err = d.client.CreateClass(ctx, d.config.Class)
+	if err != nil {
+		return fmt.Errorf("class %v does not exist and could not be created: %w", d.config.Class, err)
+	}
+
+	return nil
+}
+
+func errOrFalse(err error) error {
+	if err != nil {
+		return err
+	}
+	return errors.New("check returned false")
+}
+
+func parseVersion(s string) ([3]int, error) {
+	var v [3]int
+
+	// ignore suffixes such as in 1.25.0-rc.0
+	s, _, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, errors.New("expected a version in the form major.minor.patch")
+	}
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return v, fmt.Errorf("invalid version part %q: %w", p, err)
+		}
+		v[i] = n
+	}
+
+	return v, nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+func formatVersion(v [3]int) string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// weaviateURL returns the URL of the Weaviate instance for error messages.
+func (d *Destination) weaviateURL() string {
+	u, err := d.config.EndpointURL()
+	if err != nil {
+		return d.config.Endpoint
+	}
+	return u.String()
+}
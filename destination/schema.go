@@ -0,0 +1,52 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+)
+
+// propertyType returns the data type of the property of the class, or an
+// empty string if the class or the property doesn't exist (yet), e.g. before
+// auto-schema created it. The types of existing properties are cached, as
+// they can't change.
+func (d *Destination) propertyType(ctx context.Context, class, property string) (string, error) {
+	key := class + "." + property
+	if dataType, ok := d.propertyTypes.Load(key); ok {
+		return dataType.(string), nil
+	}
+
+	exists, err := d.client.ClassExists(ctx, class)
+	if err != nil || !exists {
+		return "", err
+	}
+	cls, err := d.client.Class(ctx, class)
+	if err != nil {
+		return "", err
+	}
+
+	var dataType string
+	for _, p := range cls.Properties {
+		if len(p.DataType) == 0 {
+			continue
+		}
+		d.propertyTypes.Store(class+"."+p.Name, p.DataType[0])
+		if p.Name == property {
+			dataType = p.DataType[0]
+		}
+	}
+
+	return dataType, nil
+}
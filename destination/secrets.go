@@ -0,0 +1,54 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/config"
+)
+
+// resolveSecrets replaces `env:` and `file:` references in the credential
+// parameters with the secrets they reference.
+func (d *Destination) resolveSecrets() error {
+	err := d.config.Config.ResolveSecrets()
+	if err != nil {
+		return err
+	}
+
+	err = config.ResolveSecrets(map[string]*string{
+		"moduleHeader.value":           &d.config.ModuleHeader.Value,
+		"providers.openai.apiKey":      &d.config.Providers.OpenAI.APIKey,
+		"providers.azureOpenai.apiKey": &d.config.Providers.AzureOpenAI.APIKey,
+		"providers.cohere.apiKey":      &d.config.Providers.Cohere.APIKey,
+		"providers.huggingface.apiKey": &d.config.Providers.HuggingFace.APIKey,
+		"providers.voyageai.apiKey":    &d.config.Providers.VoyageAI.APIKey,
+		"providers.jina.apiKey":        &d.config.Providers.Jina.APIKey,
+		"embedding.openai.apiKey":      &d.config.Embedding.OpenAI.APIKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, value := range d.config.Secrets {
+		secret, err := config.ResolveSecret(value)
+		if err != nil {
+			return fmt.Errorf("error resolving secrets.%v: %w", name, err)
+		}
+		d.config.Secrets[name] = secret
+	}
+
+	return nil
+}
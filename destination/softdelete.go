@@ -0,0 +1,74 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// softDelete marks obj as deleted, keeping its other properties. The
+// properties of obj, e.g. the version, are merged into the object too.
+// Objects which don't exist are ignored, in which case false is returned.
+func (d *Destination) softDelete(ctx context.Context, record opencdc.Record, obj *weaviate.Object) (bool, error) {
+	cfg := d.config.SoftDelete
+
+	obj.Properties[cfg.Property] = true
+	if cfg.TimestampProperty != "" {
+		deletedAt, err := record.Metadata.GetReadAt()
+		if err != nil {
+			deletedAt = time.Now()
+		}
+		obj.Properties[cfg.TimestampProperty] = deletedAt.UTC().Format(time.RFC3339Nano)
+	}
+	if d.config.ContentHash.Enabled {
+		// the hash of the object before the delete must not match the
+		// object created next
+		obj.Properties[d.config.ContentHash.Property] = ""
+	}
+
+	err := d.client.Merge(ctx, obj)
+	if errors.Is(err, weaviate.ErrNotFound) {
+		sdk.Logger(ctx).Debug().
+			Str("class", obj.Class).
+			Str("id", obj.ID).
+			Msg("deleted object doesn't exist, ignoring delete")
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// revive marks a created or updated object as not deleted. The time of a
+// previous delete, e.g. read from Weaviate by the source, is removed.
+func (d *Destination) revive(obj *weaviate.Object) {
+	cfg := d.config.SoftDelete
+	if !cfg.Enabled {
+		return
+	}
+
+	obj.Properties[cfg.Property] = false
+	if cfg.TimestampProperty != "" {
+		delete(obj.Properties, cfg.TimestampProperty)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// storedKey is the context key of the values fetched by fetchStored.
+type storedKey struct{}
+
+// objectKey identifies an object across classes and tenants.
+type objectKey struct {
+	class  string
+	tenant string
+	id     string
+}
+
+func newObjectKey(obj *weaviate.Object) objectKey {
+	return objectKey{class: obj.Class, tenant: obj.Tenant, id: obj.ID}
+}
+
+// storedValues holds the values of the properties stored on the objects of a
+// batch of records, which are fetched before the batch is written, and
+// updated as the objects are written. The records of an object are written
+// by the same worker, in order, so they see the values written by the
+// previous records.
+type storedValues struct {
+	mu     sync.Mutex
+	values map[objectKey]map[string]string
+}
+
+// storedProperties returns the properties which are fetched before a batch
+// of records is written, i.e. the content hash and the version.
+func (d *Destination) storedProperties() []string {
+	var properties []string
+	if d.config.ContentHash.Enabled {
+		properties = append(properties, d.config.ContentHash.Property)
+	}
+	if d.config.VersionField != "" {
+		properties = append(properties, d.config.VersionProperty)
+	}
+	return properties
+}
+
+// fetchStored fetches the stored properties of the objects of the records,
+// with a request per class and tenant, and returns a context with them.
+// Properties which don't exist in the class yet aren't stored on any object.
+// If fetching fails, the context has no values, so that all objects are
+// written, unless versions are stored, as outdated records would overwrite
+// objects.
+func (d *Destination) fetchStored(ctx context.Context, records []opencdc.Record) (context.Context, error) {
+	type collection struct {
+		class  string
+		tenant string
+	}
+
+	var (
+		order []collection
+		ids   = make(map[collection][]string)
+		seen  = make(map[objectKey]bool, len(records))
+	)
+	for _, record := range records {
+		key := objectKey{
+			class:  d.recordClass(record),
+			tenant: record.Metadata[MetadataTenant],
+			id:     d.recordUUID(record),
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		c := collection{class: key.class, tenant: key.tenant}
+		if _, ok := ids[c]; !ok {
+			order = append(order, c)
+		}
+		ids[c] = append(ids[c], key.id)
+	}
+
+	stored := &storedValues{values: make(map[objectKey]map[string]string)}
+	for _, c := range order {
+		values, err := d.fetchCollection(ctx, c.class, c.tenant, ids[c])
+		if err != nil && d.config.VersionField != "" {
+			return nil, fmt.Errorf("error fetching stored properties of class %v: %w", c.class, err)
+		}
+		if err != nil {
+			sdk.Logger(ctx).Warn().
+				Err(err).
+				Str("class", c.class).
+				Str("tenant", c.tenant).
+				Msg("error fetching stored properties, writing all objects")
+			return ctx, nil
+		}
+		for id, v := range values {
+			stored.values[objectKey{class: c.class, tenant: c.tenant, id: id}] = v
+		}
+	}
+
+	return context.WithValue(ctx, storedKey{}, stored), nil
+}
+
+// fetchCollection fetches the stored properties existing in the class of
+// the objects with the IDs.
+func (d *Destination) fetchCollection(ctx context.Context, class, tenant string, ids []string) (map[string]map[string]string, error) {
+	var properties []string
+	for _, property := range d.storedProperties() {
+		dataType, err := d.propertyType(ctx, class, property)
+		if err != nil {
+			return nil, fmt.Errorf("error getting type of %v: %w", property, err)
+		}
+		if dataType != "" {
+			properties = append(properties, property)
+		}
+	}
+	if len(properties) == 0 {
+		return nil, nil
+	}
+
+	return d.client.TextProperties(ctx, class, tenant, properties, ids)
+}
+
+// storedValue returns the value of the property stored on the object, if it
+// was fetched.
+func storedValue(ctx context.Context, key objectKey, property string) (string, bool) {
+	stored, _ := ctx.Value(storedKey{}).(*storedValues)
+	if stored == nil {
+		return "", false
+	}
+
+	stored.mu.Lock()
+	defer stored.mu.Unlock()
+	value, ok := stored.values[key][property]
+	return value, ok
+}
+
+// written updates the stored properties of a written object, or removes
+// them if the object was deleted.
+func (d *Destination) written(ctx context.Context, key objectKey, obj *weaviate.Object) {
+	stored, _ := ctx.Value(storedKey{}).(*storedValues)
+	if stored == nil {
+		return
+	}
+
+	stored.mu.Lock()
+	defer stored.mu.Unlock()
+	if obj == nil {
+		delete(stored.values, key)
+		return
+	}
+
+	values := make(map[string]string)
+	for _, property := range d.storedProperties() {
+		if value, ok := obj.Properties[property].(string); ok {
+			values[property] = value
+		}
+	}
+	stored.values[key] = values
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// throttler limits the rate at which records are written to Weaviate. The
+// rate is adjusted using AIMD (additive increase, multiplicative decrease):
+// every successful write increases the rate by a constant, every rate-limited
+// write multiplies it with a factor lower than 1.
+type throttler struct {
+	config ThrottleConfig
+
+	mu      sync.Mutex
+	rate    float64
+	next    time.Time
+	limited bool
+}
+
+func newThrottler(config ThrottleConfig) *throttler {
+	return &throttler{
+		config: config,
+		rate:   config.InitialRate,
+	}
+}
+
+// Wait blocks until the next write is allowed under the current rate.
+func (t *throttler) Wait(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	at := t.next
+	t.next = t.next.Add(time.Duration(float64(time.Second) / t.rate))
+	t.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Success increases the rate additively and returns the new rate. The
+// returned bool is true if the rate just recovered to the maximum rate after
+// having been decreased.
+func (t *throttler) Success() (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rate = min(t.rate+t.config.Increase, t.config.MaxRate)
+	recovered := t.limited && t.rate == t.config.MaxRate
+	if recovered {
+		t.limited = false
+	}
+	return t.rate, recovered
+}
+
+// RateLimited decreases the rate multiplicatively and returns the new rate.
+func (t *throttler) RateLimited() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rate = max(t.rate*t.config.Decrease, t.config.MinRate)
+	t.limited = true
+	return t.rate
+}
+
+// Rate returns the current rate in records per second.
+func (t *throttler) Rate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.rate
+}
@@ -0,0 +1,202 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// versionMetadataPrefix is the prefix of a versionField naming a metadata
+// field instead of a payload field.
+const versionMetadataPrefix = "metadata."
+
+// skip sets the soft delete properties, the version and the content hash of
+// obj, and returns true if the record is outdated or doesn't change the
+// object.
+func (d *Destination) skip(ctx context.Context, record opencdc.Record, obj *weaviate.Object) (bool, error) {
+	if obj.Properties == nil {
+		obj.Properties = make(map[string]any)
+	}
+	d.revive(obj)
+
+	outdated, err := d.outdated(ctx, record, obj)
+	if err != nil || outdated {
+		return outdated, err
+	}
+
+	return d.unchanged(ctx, obj), nil
+}
+
+// mergeVersion stores the version of obj on the object, if it's newer than
+// the stored version, without writing the other properties. Unchanged
+// objects aren't written, but their version is, so that records older than
+// the unchanged record are still recognized as outdated.
+func (d *Destination) mergeVersion(ctx context.Context, obj *weaviate.Object) error {
+	if d.config.VersionField == "" {
+		return nil
+	}
+	version, ok := obj.Properties[d.config.VersionProperty].(string)
+	if !ok {
+		return nil
+	}
+	key := newObjectKey(obj)
+	if stored, ok := storedValue(ctx, key, d.config.VersionProperty); ok && !newerVersion(version, stored) {
+		return nil
+	}
+
+	err := d.client.Merge(ctx, &weaviate.Object{
+		ID:         obj.ID,
+		Class:      obj.Class,
+		Tenant:     obj.Tenant,
+		Properties: map[string]any{d.config.VersionProperty: version},
+	})
+	if err != nil {
+		return fmt.Errorf("error storing version: %w", err)
+	}
+	d.written(ctx, key, obj)
+
+	return nil
+}
+
+// newerVersion returns true if version is newer than the stored version, or
+// if the stored version is invalid.
+func newerVersion(version, stored string) bool {
+	v, _ := strconv.ParseInt(version, 10, 64)
+	s, err := strconv.ParseInt(stored, 10, 64)
+	return err != nil || v > s
+}
+
+// outdated returns true if the version of the record is older than the
+// version stored on the object. The version of records which create or
+// update obj, or soft delete it, is stored in the configured property.
+// Deletes without a version are never outdated.
+func (d *Destination) outdated(ctx context.Context, record opencdc.Record, obj *weaviate.Object) (bool, error) {
+	if d.config.VersionField == "" {
+		return false, nil
+	}
+
+	version, ok, err := d.recordVersion(record)
+	if err != nil {
+		return false, fmt.Errorf("invalid version: %w", err)
+	}
+	switch {
+	case !ok && record.Operation == opencdc.OperationDelete:
+		return false, nil
+	case !ok:
+		return false, fmt.Errorf("record has no version field %v", d.config.VersionField)
+	case obj.Properties != nil:
+		// the properties of deletes are only set by soft deletes
+		obj.Properties[d.config.VersionProperty] = strconv.FormatInt(version, 10)
+	}
+
+	value, ok := storedValue(ctx, newObjectKey(obj), d.config.VersionProperty)
+	if !ok {
+		return false, nil
+	}
+	stored, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		sdk.Logger(ctx).Warn().
+			Str("class", obj.Class).
+			Str("id", obj.ID).
+			Str("version", value).
+			Msg("invalid stored version, overwriting object")
+		return false, nil
+	}
+	if version >= stored {
+		return false, nil
+	}
+
+	sdk.Logger(ctx).Debug().
+		Str("class", obj.Class).
+		Str("id", obj.ID).
+		Int64("version", version).
+		Int64("storedVersion", stored).
+		Msgf("outdated %v, skipping write", record.Operation)
+	return true, nil
+}
+
+// recordVersion returns the version of the record, read from the configured
+// payload or metadata field. Deletes are read from the payload before the
+// change. ok is false if the record has no version.
+func (d *Destination) recordVersion(record opencdc.Record) (_ int64, ok bool, _ error) {
+	if name, ok := strings.CutPrefix(d.config.VersionField, versionMetadataPrefix); ok {
+		value := record.Metadata[name]
+		if value == "" {
+			return 0, false, nil
+		}
+		version, err := parseRecordVersion(value)
+		return version, err == nil, err
+	}
+
+	data := record.Payload.After
+	if record.Operation == opencdc.OperationDelete {
+		data = record.Payload.Before
+	}
+	if data == nil || len(data.Bytes()) == 0 {
+		return 0, false, nil
+	}
+
+	// numbers are decoded as json.Number, as large integers, e.g. Unix
+	// timestamps in nanoseconds, don't fit into a float64
+	var payload map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data.Bytes()))
+	dec.UseNumber()
+	err := dec.Decode(&payload)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	value, ok := payload[d.config.VersionField]
+	if !ok || value == nil {
+		return 0, false, nil
+	}
+	version, err := parseRecordVersion(value)
+	return version, err == nil, err
+}
+
+// parseRecordVersion parses an integer version, or an RFC 3339 timestamp, which is
+// converted to Unix nanoseconds.
+func parseRecordVersion(value any) (int64, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, fmt.Errorf("%v is a %T, not an integer or timestamp", value, value)
+	}
+
+	version, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return version, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an integer or RFC 3339 timestamp", s)
+	}
+
+	return t.UnixNano(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destination
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// writeConcurrently writes records using the configured number of workers.
+// Records are partitioned by their object ID, and every worker writes the
+// records of its partitions in the order in which they were received.
+//
+// If a record fails, the returned count is the index of the first failed
+// record, i.e. all records before it have been written. Records after it may
+// have been written too, and are written again when they are retried. This
+// doesn't fail, as inserts replace existing objects when there are multiple
+// workers, updating a missing object creates it and deleting a missing object
+// succeeds.
+func (d *Destination) writeConcurrently(ctx context.Context, records []opencdc.Record) (int, error) {
+	partitions := make([][]int, d.config.Workers)
+	for i, record := range records {
+		p := d.partition(record)
+		partitions[p] = append(partitions[p], i)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		failedAt = len(records)
+	)
+	// failed returns true if a record before index i failed already,
+	// in which case there is no need to write the record at i.
+	failed := func(i int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return failedAt < i
+	}
+
+	for _, indices := range partitions {
+		if len(indices) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for _, i := range indices {
+				if failed(i) {
+					return
+				}
+
+				err := d.write(ctx, records[i])
+				if err == nil {
+					continue
+				}
+
+				mu.Lock()
+				if i < failedAt {
+					failedAt = i
+					firstErr = fmt.Errorf("error routing %v: %w", records[i].Operation, err)
+				}
+				mu.Unlock()
+				return
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return failedAt, firstErr
+	}
+
+	return len(records), nil
+}
+
+// partition returns the index of the worker responsible for the record.
+func (d *Destination) partition(record opencdc.Record) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(d.recordUUID(record)))
+	return int(h.Sum32() % uint32(d.config.Workers)) //nolint:gosec // the number of workers is always positive
+}
@@ -0,0 +1,233 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/config"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// configureAuth returns an HTTP client authenticating with the mechanism
+// selected in cfg, using client to send the requests. Static credentials (API
+// keys and bearer tokens) are added to headers instead, so that they are also
+// used for gRPC requests. If the credentials are read from a file or
+// obtained using OIDC, the returned tokenSource is used to add them to gRPC
+// requests.
+func configureAuth(cfg Config, client *http.Client, headers map[string]string) (*http.Client, tokenSource, error) {
+	switch cfg.AuthMechanism {
+	case "", config.AuthMechanismNone:
+		return client, nil, nil
+	case config.AuthMechanismAPIKey:
+		if cfg.APIKeyFile != "" {
+			return withFileToken(client, cfg.APIKeyFile)
+		}
+		headers["Authorization"] = "Bearer " + cfg.APIKey
+		return client, nil, nil
+	case config.AuthMechanismBearerToken:
+		if cfg.BearerToken.File != "" {
+			return withFileToken(client, cfg.BearerToken.File)
+		}
+		headers["Authorization"] = "Bearer " + cfg.BearerToken.Token
+		return client, nil, nil
+	case config.AuthMechanismWCSCreds, config.AuthMechanismOIDCClientCredentials:
+		return oidcClient(cfg, client)
+	default:
+		return nil, nil, fmt.Errorf("unknown auth mechanism %q", cfg.AuthMechanism)
+	}
+}
+
+func withFileToken(client *http.Client, path string) (*http.Client, tokenSource, error) {
+	token, err := newFileToken(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &http.Client{
+		Transport: &tokenTransport{base: client.Transport, token: token},
+		Timeout:   client.Timeout,
+	}, token, nil
+}
+
+// oidcConfig is the OIDC configuration advertised by Weaviate.
+type oidcConfig struct {
+	Href     string   `json:"href"`
+	ClientID string   `json:"clientId"`
+	Scopes   []string `json:"scopes"`
+
+	tokenURL string
+}
+
+// oidcToken is a token obtained using OIDC. The underlying token source
+// refreshes the token when it expires.
+type oidcToken struct {
+	source oauth2.TokenSource
+
+	mu    sync.Mutex
+	token string
+}
+
+// Token returns a valid token. The token is only fetched again once it
+// expired, force is ignored.
+func (o *oidcToken) Token(bool) (string, error) {
+	token, err := o.source.Token()
+	if err != nil {
+		return "", fmt.Errorf("error getting token: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.token = token.AccessToken
+
+	return o.token, nil
+}
+
+func (o *oidcToken) cached() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.token
+}
+
+// oidcClient returns an HTTP client getting tokens using the OIDC password
+// flow (WCS credentials) or the client credentials flow, and the source of
+// the tokens, so that they can also be added to gRPC requests. The token
+// requests are sent using client, so that they use the same TLS and proxy
+// settings.
+func oidcClient(cfg Config, client *http.Client) (*http.Client, tokenSource, error) {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+
+	cc := cfg.ClientCredentials
+	if cfg.AuthMechanism == config.AuthMechanismOIDCClientCredentials && cc.TokenURL != "" {
+		ccfg := clientcredentials.Config{
+			ClientID:     cc.ClientID,
+			ClientSecret: cc.ClientSecret,
+			TokenURL:     cc.TokenURL,
+			Scopes:       cc.Scopes,
+		}
+		return withTokenSource(ctx, ccfg.TokenSource(ctx), client)
+	}
+
+	oidc, err := discoverOIDC(ctx, client, cfg.Scheme+"://"+cfg.Endpoint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting OIDC configuration: %w", err)
+	}
+	if oidc == nil {
+		sdk.Logger(ctx).Warn().
+			Str("mechanism", cfg.AuthMechanism).
+			Msg("Weaviate is configured without OIDC authentication, sending unauthenticated requests")
+		return client, nil, nil
+	}
+
+	if cfg.AuthMechanism == config.AuthMechanismOIDCClientCredentials {
+		ccfg := clientcredentials.Config{
+			ClientID:     oidc.ClientID,
+			ClientSecret: cc.ClientSecret,
+			TokenURL:     oidc.tokenURL,
+			Scopes:       append(cc.Scopes, oidc.Scopes...),
+		}
+		if cc.ClientID != "" {
+			ccfg.ClientID = cc.ClientID
+		}
+		return withTokenSource(ctx, ccfg.TokenSource(ctx), client)
+	}
+
+	ocfg := oauth2.Config{
+		ClientID: oidc.ClientID,
+		Endpoint: oauth2.Endpoint{TokenURL: oidc.tokenURL},
+		Scopes:   append([]string{"offline_access"}, oidc.Scopes...),
+	}
+	token, err := ocfg.PasswordCredentialsToken(ctx, cfg.WCSAuth.Username, cfg.WCSAuth.Password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting token: %w", err)
+	}
+
+	return withTokenSource(ctx, ocfg.TokenSource(ctx, token), client)
+}
+
+// withTokenSource returns an HTTP client adding the tokens from source to
+// requests, with the same timeout as client.
+func withTokenSource(ctx context.Context, source oauth2.TokenSource, client *http.Client) (*http.Client, tokenSource, error) {
+	return withTimeout(oauth2.NewClient(ctx, source), client), &oidcToken{source: source}, nil
+}
+
+// discoverOIDC returns the OIDC configuration of the Weaviate instance at
+// baseURL, or nil if the instance doesn't use OIDC.
+func discoverOIDC(ctx context.Context, client *http.Client, baseURL string) (*oidcConfig, error) {
+	var oidc oidcConfig
+	found, err := getJSON(ctx, client, baseURL+"/v1/.well-known/openid-configuration", &oidc)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var provider struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	found, err = getJSON(ctx, client, oidc.Href, &provider)
+	if err != nil {
+		return nil, err
+	}
+	if !found || provider.TokenEndpoint == "" {
+		return nil, errors.New("could not get token endpoint from OIDC provider")
+	}
+	oidc.tokenURL = provider.TokenEndpoint
+
+	return &oidc, nil
+}
+
+// getJSON decodes the JSON response from url into v. It returns false if
+// the response status is 404.
+func getJSON(ctx context.Context, client *http.Client, url string, v any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error getting %v: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("unexpected status %v from %v: %s", resp.StatusCode, url, body)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return false, fmt.Errorf("error decoding response from %v: %w", url, err)
+	}
+
+	return true, nil
+}
+
+func withTimeout(client, from *http.Client) *http.Client {
+	client.Timeout = from.Timeout
+	return client
+}
@@ -0,0 +1,340 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/matryer/is"
+)
+
+// authServer is a stand-in for Weaviate with an OIDC provider, recording
+// the Authorization header of object requests.
+type authServer struct {
+	*httptest.Server
+
+	oidc bool
+
+	mu   sync.Mutex
+	auth []string
+}
+
+func newAuthServer(t *testing.T, oidc bool) *authServer {
+	s := &authServer{oidc: oidc}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/.well-known/openid-configuration":
+			if !s.oidc {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"href":"` + s.URL + `/oidc","clientId":"weaviate-client"}`))
+		case "/oidc":
+			_, _ = w.Write([]byte(`{"token_endpoint":"` + s.URL + `/token"}`))
+		case "/token":
+			_ = r.ParseForm()
+			clientID, _, ok := r.BasicAuth()
+			if !ok {
+				clientID = r.Form.Get("client_id")
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token-` + clientID + `-` + r.Form.Get("grant_type") + `","token_type":"bearer","expires_in":3600}`))
+		case "/v1/meta":
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+		default:
+			s.mu.Lock()
+			s.auth = append(s.auth, r.Header.Get("Authorization"))
+			s.mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestClient_Auth(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	is.New(t).NoErr(os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+
+	testCases := []struct {
+		name     string
+		oidc     bool
+		config   weaviate.Config
+		wantAuth string
+		wantErr  string
+	}{
+		{
+			name:   "none",
+			config: weaviate.Config{AuthMechanism: "none", APIKey: "ignored"},
+		},
+		{
+			name:     "API key",
+			config:   weaviate.Config{AuthMechanism: "apiKey", APIKey: "test-api-key"},
+			wantAuth: "Bearer test-api-key",
+		},
+		{
+			name: "WCS credentials",
+			oidc: true,
+			config: weaviate.Config{
+				AuthMechanism: "wcsCreds",
+				WCSAuth:       weaviate.WCSAuth{Username: "user", Password: "pass"},
+			},
+			wantAuth: "Bearer token-weaviate-client-password",
+		},
+		{
+			name: "OIDC client credentials, discovered token URL",
+			oidc: true,
+			config: weaviate.Config{
+				AuthMechanism:     "oidcClientCredentials",
+				ClientCredentials: weaviate.ClientCredentials{ClientSecret: "secret"},
+			},
+			wantAuth: "Bearer token-weaviate-client-client_credentials",
+		},
+		{
+			name: "OIDC client credentials, custom token URL",
+			config: weaviate.Config{
+				AuthMechanism: "oidcClientCredentials",
+				ClientCredentials: weaviate.ClientCredentials{
+					ClientID:     "my-client",
+					ClientSecret: "secret",
+					// set to the test server's URL below
+					TokenURL: "/token",
+				},
+			},
+			wantAuth: "Bearer token-my-client-client_credentials",
+		},
+		{
+			name: "static bearer token",
+			config: weaviate.Config{
+				AuthMechanism: "bearerToken",
+				BearerToken:   weaviate.BearerToken{Token: "static-token"},
+			},
+			wantAuth: "Bearer static-token",
+		},
+		{
+			name: "bearer token file",
+			config: weaviate.Config{
+				AuthMechanism: "bearerToken",
+				BearerToken:   weaviate.BearerToken{File: tokenFile},
+			},
+			wantAuth: "Bearer file-token",
+		},
+		{
+			name: "missing bearer token file",
+			config: weaviate.Config{
+				AuthMechanism: "bearerToken",
+				BearerToken:   weaviate.BearerToken{File: filepath.Join(t.TempDir(), "missing")},
+			},
+			wantErr: "error reading token file",
+		},
+		{
+			name:    "unknown mechanism",
+			config:  weaviate.Config{AuthMechanism: "magic"},
+			wantErr: `unknown auth mechanism "magic"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+			srv := newAuthServer(t, tc.oidc)
+
+			cfg := tc.config
+			cfg.Endpoint = strings.TrimPrefix(srv.URL, "http://")
+			cfg.Scheme = "http"
+			if cfg.ClientCredentials.TokenURL != "" {
+				cfg.ClientCredentials.TokenURL = srv.URL + cfg.ClientCredentials.TokenURL
+			}
+
+			client := &weaviate.Client{}
+			err := client.Open(cfg)
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+
+			err = client.Insert(ctx, &weaviate.Object{
+				ID:    "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+				Class: "TestClass",
+			})
+			is.NoErr(err)
+
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			is.Equal(srv.auth, []string{tc.wantAuth})
+		})
+	}
+}
+
+func TestClient_GRPC_OIDC(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   weaviate.Config
+		wantAuth string
+	}{
+		{
+			name: "WCS credentials",
+			config: weaviate.Config{
+				AuthMechanism: "wcsCreds",
+				WCSAuth:       weaviate.WCSAuth{Username: "user", Password: "pass"},
+			},
+			wantAuth: "Bearer token-weaviate-client-password",
+		},
+		{
+			name: "OIDC client credentials",
+			config: weaviate.Config{
+				AuthMechanism:     "oidcClientCredentials",
+				ClientCredentials: weaviate.ClientCredentials{ClientSecret: "secret"},
+			},
+			wantAuth: "Bearer token-weaviate-client-client_credentials",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+			srv := newAuthServer(t, true)
+			grpcSrv := newGRPCServer(t)
+
+			cfg := tc.config
+			cfg.Endpoint = strings.TrimPrefix(srv.URL, "http://")
+			cfg.Scheme = "http"
+			cfg.Transport = weaviate.TransportGRPC
+			cfg.GRPC = weaviate.GRPCConfig{Address: grpcSrv.addr}
+
+			client := &weaviate.Client{}
+			is.NoErr(client.Open(cfg))
+			defer client.Close()
+
+			err := client.Insert(ctx, &weaviate.Object{
+				ID:    "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+				Class: "TestClass",
+			})
+			is.NoErr(err)
+
+			srv.mu.Lock()
+			is.Equal(len(srv.auth), 0) // expected the object to be written using gRPC
+			srv.mu.Unlock()
+
+			grpcSrv.mu.Lock()
+			defer grpcSrv.mu.Unlock()
+			is.Equal(grpcSrv.auth, []string{tc.wantAuth})
+		})
+	}
+}
+
+func TestClient_TokenFileRotation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config func(file string) weaviate.Config
+	}{
+		{
+			name: "API key file",
+			config: func(file string) weaviate.Config {
+				return weaviate.Config{AuthMechanism: "apiKey", APIKeyFile: file}
+			},
+		},
+		{
+			name: "bearer token file",
+			config: func(file string) weaviate.Config {
+				return weaviate.Config{AuthMechanism: "bearerToken", BearerToken: weaviate.BearerToken{File: file}}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			var mu sync.Mutex
+			validToken := "token-1"
+			var got []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/meta" {
+					_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				auth := r.Header.Get("Authorization")
+				got = append(got, auth)
+				if auth != "Bearer "+validToken {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			file := filepath.Join(t.TempDir(), "token")
+			writeToken := func(token string, modTime time.Time) {
+				is.NoErr(os.WriteFile(file, []byte(token), 0o600))
+				is.NoErr(os.Chtimes(file, modTime, modTime))
+			}
+			rotate := func(token string) {
+				mu.Lock()
+				defer mu.Unlock()
+				validToken = token
+				got = nil
+			}
+			start := time.Now().Add(-time.Hour)
+			writeToken("token-1", start)
+
+			cfg := tc.config(file)
+			cfg.Endpoint = strings.TrimPrefix(srv.URL, "http://")
+			cfg.Scheme = "http"
+			client := &weaviate.Client{}
+			is.NoErr(client.Open(cfg))
+
+			obj := &weaviate.Object{ID: "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc", Class: "TestClass"}
+			is.NoErr(client.Insert(ctx, obj))
+
+			// the file changed, the new token is used right away
+			rotate("token-2")
+			writeToken("token-2", start.Add(time.Minute))
+			is.NoErr(client.Insert(ctx, obj))
+			is.Equal(got, []string{"Bearer token-2"})
+
+			// the file changed without a visible change of its modification
+			// time or size, the token is read again after a 401
+			rotate("token-3")
+			writeToken("token-3", start.Add(time.Minute))
+			is.NoErr(client.Insert(ctx, obj))
+			is.Equal(got, []string{"Bearer token-2", "Bearer token-3"})
+
+			// the token was revoked and not rotated
+			rotate("token-4")
+			err := client.Insert(ctx, obj)
+			is.True(err != nil)
+			is.Equal(got, []string{"Bearer token-3"})
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
+)
+
+// UpdatedObjects returns up to limit objects of the class and tenant matching
+// where, if not nil, and updated after the object last updated at since (in
+// Unix milliseconds) with the ID afterID, ordered by update time and ID. If
+// afterID is empty, all objects updated at or after since are returned. The
+// tenant is empty for classes without multi-tenancy. Only the ID and the
+// timestamps of the objects are set, Object returns the complete object.
+//
+// The objects are paginated by update time and ID instead of an offset, as
+// Weaviate limits the number of objects an offset can skip.
+func (c *Client) UpdatedObjects(
+	ctx context.Context,
+	class, tenant string,
+	where *filters.WhereBuilder,
+	since int64,
+	afterID string,
+	limit int,
+) (_ []*Object, err error) {
+	defer c.redact(ctx, &err)
+
+	filter := filters.Where().
+		WithPath([]string{"_lastUpdateTimeUnix"}).
+		WithOperator(filters.GreaterThanEqual).
+		WithValueText(strconv.FormatInt(since, 10))
+	if afterID != "" {
+		filter = filters.Where().
+			WithOperator(filters.Or).
+			WithOperands([]*filters.WhereBuilder{
+				filters.Where().
+					WithPath([]string{"_lastUpdateTimeUnix"}).
+					WithOperator(filters.GreaterThan).
+					WithValueText(strconv.FormatInt(since, 10)),
+				filters.Where().
+					WithOperator(filters.And).
+					WithOperands([]*filters.WhereBuilder{
+						filters.Where().
+							WithPath([]string{"_lastUpdateTimeUnix"}).
+							WithOperator(filters.Equal).
+							WithValueText(strconv.FormatInt(since, 10)),
+						filters.Where().
+							WithPath([]string{"id"}).
+							WithOperator(filters.GreaterThan).
+							WithValueText(afterID),
+					}),
+			})
+	}
+	if where != nil {
+		filter = filters.Where().
+			WithOperator(filters.And).
+			WithOperands([]*filters.WhereBuilder{where, filter})
+	}
+
+	return c.objectMetadata(
+		ctx,
+		class,
+		tenant,
+		c.client.GraphQL().Get().
+			WithWhere(filter).
+			WithSort(
+				graphql.Sort{Path: []string{"_lastUpdateTimeUnix"}, Order: graphql.Asc},
+				graphql.Sort{Path: []string{"_id"}, Order: graphql.Asc},
+			).
+			WithLimit(limit),
+	)
+}
+
+// LastUpdatedObject returns the object of the class and tenant matching
+// where, if not nil, that was updated last, or nil if there is no such object.
+// Only the ID and the timestamps of the object are set.
+func (c *Client) LastUpdatedObject(ctx context.Context, class, tenant string, where *filters.WhereBuilder) (_ *Object, err error) {
+	defer c.redact(ctx, &err)
+
+	get := c.client.GraphQL().Get()
+	if where != nil {
+		get = get.WithWhere(where)
+	}
+	objects, err := c.objectMetadata(
+		ctx,
+		class,
+		tenant,
+		get.
+			WithSort(
+				graphql.Sort{Path: []string{"_lastUpdateTimeUnix"}, Order: graphql.Desc},
+				graphql.Sort{Path: []string{"_id"}, Order: graphql.Desc},
+			).
+			WithLimit(1),
+	)
+	if err != nil || len(objects) == 0 {
+		return nil, err
+	}
+
+	return objects[0], nil
+}
+
+// objectMetadata runs the query and returns the matching objects with only
+// their ID and timestamps set.
+func (c *Client) objectMetadata(ctx context.Context, class, tenant string, get *graphql.GetBuilder) ([]*Object, error) {
+	resp, err := get.
+		WithClassName(class).
+		WithTenant(tenant).
+		WithFields(graphql.Field{
+			Name: "_additional",
+			Fields: []graphql.Field{
+				{Name: "id"},
+				{Name: "creationTimeUnix"},
+				{Name: "lastUpdateTimeUnix"},
+			},
+		}).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting objects: %w", classifyError(err))
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("error getting objects: %v", resp.Errors[0].Message)
+	}
+
+	// re-encode the generic response to decode the objects
+	data, err := json.Marshal(resp.Data["Get"])
+	if err != nil {
+		return nil, fmt.Errorf("error encoding response: %w", err)
+	}
+	var results map[string][]struct {
+		Additional struct {
+			ID                 string `json:"id"`
+			CreationTimeUnix   string `json:"creationTimeUnix"`
+			LastUpdateTimeUnix string `json:"lastUpdateTimeUnix"`
+		} `json:"_additional"`
+	}
+	err = json.Unmarshal(data, &results)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	classResults := resultsOf(results, class)
+	objects := make([]*Object, len(classResults))
+	for i, r := range classResults {
+		created, err := strconv.ParseInt(r.Additional.CreationTimeUnix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid creation time of object %v: %w", r.Additional.ID, err)
+		}
+		updated, err := strconv.ParseInt(r.Additional.LastUpdateTimeUnix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid update time of object %v: %w", r.Additional.ID, err)
+		}
+
+		objects[i] = &Object{
+			ID:                 r.Additional.ID,
+			Class:              class,
+			Tenant:             tenant,
+			CreationTimeUnix:   created,
+			LastUpdateTimeUnix: updated,
+		}
+	}
+
+	return objects, nil
+}
+
+// Object returns the object of the class and tenant with the given ID, or nil
+// if it doesn't exist.
+func (c *Client) Object(ctx context.Context, class, tenant, id string) (_ *Object, err error) {
+	defer c.redact(ctx, &err)
+
+	objects, err := c.client.Data().ObjectsGetter().
+		WithClassName(class).
+		WithTenant(tenant).
+		WithID(id).
+		WithVector().
+		Do(ctx)
+	if err != nil {
+		var wErr *fault.WeaviateClientError
+		if errors.As(err, &wErr) && wErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting object: %w", classifyError(err))
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	return newObject(objects[0]), nil
+}
+
+// IDs returns up to limit IDs of objects of the class and tenant greater than
+// after, ordered by ID. An empty after returns the first IDs. Only the IDs are
+// fetched, which makes this cheaper than Objects.
+func (c *Client) IDs(ctx context.Context, class, tenant, after string, limit int) (_ []string, err error) {
+	defer c.redact(ctx, &err)
+
+	// an empty after is sent too, to use the cursor API from the start
+	objects, err := c.objectMetadata(ctx, class, tenant, c.client.GraphQL().Get().WithAfter(after).WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(objects))
+	for i, obj := range objects {
+		ids[i] = obj.ID
+	}
+
+	return ids, nil
+}
+
+// TextProperties returns the values of the text properties of the objects
+// of the class and tenant with the given IDs, keyed by ID and property.
+// Objects that don't exist are left out, as are properties without a value.
+func (c *Client) TextProperties(ctx context.Context, class, tenant string, properties, ids []string) (_ map[string]map[string]string, err error) {
+	defer c.redact(ctx, &err)
+
+	fields := make([]graphql.Field, 0, len(properties)+1)
+	for _, p := range properties {
+		fields = append(fields, graphql.Field{Name: p})
+	}
+	fields = append(fields, graphql.Field{Name: "_additional", Fields: []graphql.Field{{Name: "id"}}})
+
+	resp, err := c.client.GraphQL().Get().
+		WithClassName(class).
+		WithTenant(tenant).
+		WithWhere(filters.Where().
+			WithPath([]string{"id"}).
+			WithOperator(filters.ContainsAny).
+			WithValueText(ids...)).
+		WithLimit(len(ids)).
+		WithFields(fields...).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting objects: %w", classifyError(err))
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("error getting objects: %v", resp.Errors[0].Message)
+	}
+
+	// re-encode the generic response to decode the objects
+	data, err := json.Marshal(resp.Data["Get"])
+	if err != nil {
+		return nil, fmt.Errorf("error encoding response: %w", err)
+	}
+	var results map[string][]map[string]any
+	err = json.Unmarshal(data, &results)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	classResults := resultsOf(results, class)
+	values := make(map[string]map[string]string, len(classResults))
+	for _, r := range classResults {
+		additional, _ := r["_additional"].(map[string]any)
+		id, _ := additional["id"].(string)
+		if id == "" {
+			continue
+		}
+		values[id] = make(map[string]string, len(properties))
+		for _, p := range properties {
+			if value, ok := r[p].(string); ok {
+				values[id][p] = value
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// resultsOf returns the results of a GraphQL Get query for the class. The
+// results are keyed by the class name as stored by Weaviate, which starts
+// with a capital letter even if the class was configured in lower case.
+func resultsOf[T any](results map[string][]T, class string) []T {
+	if r, ok := results[class]; ok {
+		return r
+	}
+	for name, r := range results {
+		if strings.EqualFold(name, class) {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/data/replication"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/db"
+	grpcbatch "github.com/weaviate/weaviate-go-client/v4/weaviate/grpc/batch"
+	"github.com/weaviate/weaviate/entities/models"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const grpcHealthCheckTimeout = 5 * time.Second
+
+// grpcClient writes and deletes objects using Weaviate's gRPC API.
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	client  pb.WeaviateClient
+	batch   grpcbatch.Batch
+	headers metadata.MD
+	// token is used for authentication, if the token is read from a file or
+	// obtained using OIDC.
+	token tokenSource
+}
+
+// newGRPCClient connects to the gRPC API and checks that it's serving. The
+// TLS configuration is used if the API is secure, the headers are sent with
+// every request, serverVersion is used to decide how vectors are encoded.
+func newGRPCClient(
+	config GRPCConfig,
+	tlsConfig *tls.Config,
+	headers map[string]string,
+	token tokenSource,
+	serverVersion func() string,
+) (*grpcClient, error) {
+	creds := insecure.NewCredentials()
+	if config.Secure {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(config.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("error creating gRPC client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcHealthCheckTimeout)
+	defer cancel()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error checking gRPC health: %w", err)
+	}
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		_ = conn.Close()
+		return nil, fmt.Errorf("gRPC API is not serving (status %v)", resp.GetStatus())
+	}
+
+	return &grpcClient{
+		conn:   conn,
+		client: pb.NewWeaviateClient(conn),
+		batch: grpcbatch.New(db.NewGRPCVersionSupport(
+			db.NewVersionProvider(serverVersion),
+		)),
+		headers: metadata.New(headers),
+		token:   token,
+	}, nil
+}
+
+// BatchObjects writes objects using a batch request. Existing objects with
+// the same ID are replaced.
+func (g *grpcClient) BatchObjects(ctx context.Context, objs ...*Object) error {
+	batch := make([]*models.Object, len(objs))
+	for i, obj := range objs {
+		batch[i] = obj.model()
+	}
+	objects, err := g.batch.GetBatchObjects(batch)
+	if err != nil {
+		return fmt.Errorf("error converting object: %w", err)
+	}
+
+	var reply *pb.BatchObjectsReply
+	err = g.withAuthRetry(ctx, func(ctx context.Context) error {
+		reply, err = g.client.BatchObjects(ctx, &pb.BatchObjectsRequest{
+			Objects:          objects,
+			ConsistencyLevel: g.batch.GetConsistencyLevel(replication.ConsistencyLevel.ALL),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(reply.GetErrors()) > 0 {
+		return errors.New(reply.GetErrors()[0].GetError())
+	}
+
+	return nil
+}
+
+// BatchDelete deletes an object using a batch request matching its ID.
+func (g *grpcClient) BatchDelete(ctx context.Context, obj *Object) error {
+	var tenant *string
+	if obj.Tenant != "" {
+		tenant = &obj.Tenant
+	}
+
+	var reply *pb.BatchDeleteReply
+	err := g.withAuthRetry(ctx, func(ctx context.Context) error {
+		var err error
+		reply, err = g.client.BatchDelete(ctx, &pb.BatchDeleteRequest{
+			Collection: obj.Class,
+			Filters: &pb.Filters{
+				Operator:  pb.Filters_OPERATOR_EQUAL,
+				Target:    &pb.FilterTarget{Target: &pb.FilterTarget_Property{Property: "_id"}},
+				TestValue: &pb.Filters_ValueText{ValueText: obj.ID},
+			},
+			Verbose:          true,
+			Tenant:           tenant,
+			ConsistencyLevel: g.batch.GetConsistencyLevel(replication.ConsistencyLevel.ALL),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if reply.GetFailed() > 0 {
+		for _, o := range reply.GetObjects() {
+			if o.GetError() != "" {
+				return errors.New(o.GetError())
+			}
+		}
+		return fmt.Errorf("%v objects failed to be deleted", reply.GetFailed())
+	}
+
+	return nil
+}
+
+func (g *grpcClient) Close() error {
+	return g.conn.Close()
+}
+
+// withAuthRetry calls fn with the headers attached to the context, including
+// headers added using WithHeaders and the token, if any. If the server
+// rejected the token and a new one is available (e.g. the token file was
+// rotated), fn is retried once with the new token.
+func (g *grpcClient) withAuthRetry(ctx context.Context, fn func(context.Context) error) error {
+	if g.token == nil {
+		return fn(metadata.NewOutgoingContext(ctx, withContextHeaders(ctx, g.headers)))
+	}
+
+	token, err := g.token.Token(false)
+	if err != nil {
+		return err
+	}
+
+	err = fn(g.outgoingContext(ctx, token))
+	if status.Code(err) != codes.Unauthenticated {
+		return err
+	}
+
+	newToken, tokenErr := g.token.Token(true)
+	if tokenErr != nil || newToken == token {
+		return err
+	}
+
+	return fn(g.outgoingContext(ctx, newToken))
+}
+
+func (g *grpcClient) outgoingContext(ctx context.Context, token string) context.Context {
+	md := withContextHeaders(ctx, g.headers).Copy()
+	md.Set("authorization", "Bearer "+token)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// isUnavailable returns true if err means that the gRPC API can't be used,
+// in which case the request should be retried using HTTP.
+func isUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Client) logFallback(ctx context.Context, err error) {
+	c.redact(ctx, &err)
+	sdk.Logger(ctx).Warn().Err(err).Msg("gRPC API unavailable, falling back to HTTP")
+}
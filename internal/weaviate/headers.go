@@ -0,0 +1,76 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type headersKey struct{}
+
+// WithHeaders returns a context that makes the client send the headers with
+// requests using the context. The headers replace configured headers with
+// the same name.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, headersKey{}, headers)
+}
+
+// HeadersFromContext returns the headers added to the context using
+// WithHeaders.
+func HeadersFromContext(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(headersKey{}).(map[string]string)
+	return headers
+}
+
+// headerTransport adds the headers from the request context to requests.
+type headerTransport struct {
+	base http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers := HeadersFromContext(req.Context())
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTrip must not modify the original request
+	req = req.Clone(req.Context())
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// withContextHeaders returns md with the headers from the context added.
+func withContextHeaders(ctx context.Context, md metadata.MD) metadata.MD {
+	headers := HeadersFromContext(ctx)
+	if len(headers) == 0 {
+		return md
+	}
+
+	md = md.Copy()
+	for name, value := range headers {
+		md.Set(strings.ToLower(name), value)
+	}
+	return md
+}
@@ -0,0 +1,38 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+// Metadata fields of records, which are set by the source and used by the
+// destination.
+const (
+	MetadataClass  = "weaviate.class"
+	MetadataVector = "weaviate.vector"
+	// MetadataVectorsPrefix is the prefix of metadata fields containing a
+	// named vector, e.g. `weaviate.vectors.title`.
+	MetadataVectorsPrefix = "weaviate.vectors."
+	// MetadataTenant is the tenant of the object, if the class is
+	// multi-tenant.
+	MetadataTenant = "weaviate.tenant"
+	// MetadataCreationTimeUnix and MetadataLastUpdateTimeUnix are set by the
+	// source to the times (in Unix milliseconds) the object was created and
+	// last updated. They are ignored by the destination, as Weaviate sets
+	// them when objects are written.
+	MetadataCreationTimeUnix   = "weaviate.creationTimeUnix"
+	MetadataLastUpdateTimeUnix = "weaviate.lastUpdateTimeUnix"
+	// MetadataHeaderPrefix is the prefix of metadata fields naming the
+	// secret sent as a header with the record, e.g.
+	// `weaviate.header.X-OpenAI-Api-Key`.
+	MetadataHeaderPrefix = "weaviate.header."
+)
@@ -0,0 +1,97 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedError is an error with secrets removed from its message. The
+// wrapped error can still be checked using errors.Is and errors.As.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// configSecrets returns the secrets in the configuration, which have to be
+// removed from errors.
+func configSecrets(config Config) []string {
+	secrets := []string{
+		config.APIKey,
+		config.WCSAuth.Password,
+		config.ClientCredentials.ClientSecret,
+		config.BearerToken.Token,
+	}
+	return appendCredentialHeaders(secrets, config.Headers)
+}
+
+// appendCredentialHeaders appends the values of the headers containing
+// credentials to secrets. Other headers, such as `X-Azure-Deployment-Id`,
+// are kept in errors, as they help finding the cause.
+func appendCredentialHeaders(secrets []string, headers map[string]string) []string {
+	for k, v := range headers {
+		name := strings.ToLower(k)
+		if strings.Contains(name, "api-key") ||
+			strings.Contains(name, "authorization") ||
+			strings.Contains(name, "token") {
+			secrets = append(secrets, v)
+		}
+	}
+	return secrets
+}
+
+// redact replaces the secrets of the client, and the credential headers
+// added to the context using WithHeaders, in the message of *err.
+func (c *Client) redact(ctx context.Context, err *error) {
+	if *err == nil {
+		return
+	}
+
+	secrets := c.secrets
+	if c.token != nil {
+		secrets = append(secrets[:len(secrets):len(secrets)], c.token.cached())
+	}
+	secrets = appendCredentialHeaders(secrets[:len(secrets):len(secrets)], HeadersFromContext(ctx))
+
+	*err = redactError(*err, secrets)
+}
+
+// redactError returns err with the secrets replaced in its message, or err
+// itself if its message doesn't contain any of the secrets.
+func redactError(err error, secrets []string) error {
+	msg := err.Error()
+	redactedMsg := msg
+	for _, s := range secrets {
+		if s != "" {
+			redactedMsg = strings.ReplaceAll(redactedMsg, s, redacted)
+		}
+	}
+	if redactedMsg == msg {
+		return err
+	}
+
+	return &redactedError{msg: redactedMsg, err: err}
+}
@@ -0,0 +1,74 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/matryer/is"
+)
+
+func TestClient_RedactsSecrets(t *testing.T) {
+	is := is.New(t)
+
+	// the server echoes the credentials in its error messages
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/meta" {
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":[{"message":"rate limit reached for key ` +
+			r.Header.Get("X-OpenAI-Api-Key") + ` of deployment ` +
+			r.Header.Get("X-Azure-Deployment-Id") + `, request authorized with ` +
+			r.Header.Get("Authorization") + `"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &weaviate.Client{}
+	err := client.Open(weaviate.Config{
+		AuthMechanism: "apiKey",
+		APIKey:        "weaviate-secret-key",
+		Endpoint:      strings.TrimPrefix(srv.URL, "http://"),
+		Scheme:        "http",
+		Headers: map[string]string{
+			"X-OpenAI-Api-Key":      "sk-default",
+			"X-Azure-Deployment-Id": "my-deployment",
+		},
+	})
+	is.NoErr(err)
+
+	obj := &weaviate.Object{ID: "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc", Class: "TestClass"}
+
+	err = client.Insert(context.Background(), obj)
+	is.True(err != nil)
+	is.True(errors.Is(err, weaviate.ErrRateLimited))
+	is.True(!strings.Contains(err.Error(), "weaviate-secret-key"))
+	is.True(!strings.Contains(err.Error(), "sk-default"))
+	is.True(strings.Contains(err.Error(), "[REDACTED]"))
+	is.True(strings.Contains(err.Error(), "my-deployment")) // expected headers without credentials to be kept
+
+	ctx := weaviate.WithHeaders(context.Background(), map[string]string{"X-OpenAI-Api-Key": "sk-customer"})
+	err = client.Insert(ctx, obj)
+	is.True(err != nil)
+	is.True(!strings.Contains(err.Error(), "sk-customer"))
+	is.True(strings.Contains(err.Error(), "[REDACTED]"))
+}
@@ -0,0 +1,242 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/matryer/is"
+)
+
+func newTLSServer(t *testing.T, clientCAs *x509.CertPool) *httptest.Server {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+	}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // failing handshakes are expected
+	if clientCAs != nil {
+		srv.TLS = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+		}
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// writePEM writes the PEM encoded blocks to a new file in dir and returns its
+// path.
+func writePEM(t *testing.T, dir, name string, blocks ...*pem.Block) string {
+	var sb strings.Builder
+	for _, b := range blocks {
+		is.New(t).NoErr(pem.Encode(&sb, b))
+	}
+
+	path := filepath.Join(dir, name)
+	is.New(t).NoErr(os.WriteFile(path, []byte(sb.String()), 0o600))
+
+	return path
+}
+
+// newClientCert creates a self-signed client certificate and returns the
+// paths to the certificate and key files and a pool containing the
+// certificate.
+func newClientCert(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	is := is.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	is.NoErr(err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "conduit"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	is.NoErr(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	is.NoErr(err)
+
+	cert, err := x509.ParseCertificate(der)
+	is.NoErr(err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return writePEM(t, dir, "client.pem", &pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		writePEM(t, dir, "client-key.pem", &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		pool
+}
+
+func TestClient_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCAs := newClientCert(t, dir)
+
+	testCases := []struct {
+		name string
+		// mTLS makes the server require a client certificate.
+		mTLS bool
+		// config gets the server's CA file.
+		config  func(caFile string) weaviate.TLSConfig
+		wantErr string
+	}{
+		{
+			name:    "unknown CA",
+			config:  func(string) weaviate.TLSConfig { return weaviate.TLSConfig{} },
+			wantErr: "certificate signed by unknown authority",
+		},
+		{
+			name: "CA file",
+			config: func(caFile string) weaviate.TLSConfig {
+				return weaviate.TLSConfig{CAFile: caFile}
+			},
+		},
+		{
+			name: "insecure skip verify",
+			config: func(string) weaviate.TLSConfig {
+				return weaviate.TLSConfig{InsecureSkipVerify: true}
+			},
+		},
+		{
+			name: "server name",
+			config: func(caFile string) weaviate.TLSConfig {
+				return weaviate.TLSConfig{CAFile: caFile, ServerName: "example.com"}
+			},
+		},
+		{
+			name: "wrong server name",
+			config: func(caFile string) weaviate.TLSConfig {
+				return weaviate.TLSConfig{CAFile: caFile, ServerName: "weaviate.internal"}
+			},
+			wantErr: "certificate is valid for",
+		},
+		{
+			name: "mutual TLS",
+			mTLS: true,
+			config: func(caFile string) weaviate.TLSConfig {
+				return weaviate.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}
+			},
+		},
+		{
+			name: "mutual TLS without client certificate",
+			mTLS: true,
+			config: func(caFile string) weaviate.TLSConfig {
+				return weaviate.TLSConfig{CAFile: caFile}
+			},
+			wantErr: "error getting meta",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var srv *httptest.Server
+			if tc.mTLS {
+				srv = newTLSServer(t, clientCAs)
+			} else {
+				srv = newTLSServer(t, nil)
+			}
+			caFile := writePEM(t, t.TempDir(), "ca.pem", &pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: srv.Certificate().Raw,
+			})
+
+			client := &weaviate.Client{}
+			err := client.Open(weaviate.Config{
+				Endpoint: strings.TrimPrefix(srv.URL, "https://"),
+				Scheme:   "https",
+				TLS:      tc.config(caFile),
+			})
+			is.NoErr(err)
+
+			meta, err := client.Meta(context.Background())
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.True(strings.Contains(err.Error(), tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(meta.Version, "1.27.0")
+		})
+	}
+}
+
+func TestClient_TLS_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "not.pem")
+	is.New(t).NoErr(os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	testCases := []struct {
+		name    string
+		config  weaviate.TLSConfig
+		wantErr string
+	}{
+		{
+			name:    "missing CA file",
+			config:  weaviate.TLSConfig{CAFile: filepath.Join(dir, "missing.pem")},
+			wantErr: "error reading CA file",
+		},
+		{
+			name:    "CA file without certificates",
+			config:  weaviate.TLSConfig{CAFile: notPEM},
+			wantErr: "CA file does not contain any PEM certificates",
+		},
+		{
+			name:    "invalid client certificate",
+			config:  weaviate.TLSConfig{CertFile: notPEM, KeyFile: notPEM},
+			wantErr: "error loading client certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			client := &weaviate.Client{}
+			err := client.Open(weaviate.Config{
+				Endpoint: "localhost:8080",
+				Scheme:   "https",
+				TLS:      tc.config,
+			})
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.wantErr))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenSource provides the token the client adds to requests itself, instead
+// of sending it as a static header.
+type tokenSource interface {
+	// Token returns the current token. If force is true, the token is fetched
+	// again if possible, because the server rejected the current one.
+	Token(force bool) (string, error)
+	// cached returns the token returned last, for redacting it from errors.
+	cached() string
+}
+
+// fileToken is a token (API key or bearer token) stored in a file. The file
+// is read again whenever its modification time or size changes, so that
+// rotated tokens are picked up without restarting the connector.
+type fileToken struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+func newFileToken(path string) (*fileToken, error) {
+	f := &fileToken{path: path}
+	_, err := f.Token(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Token returns the current token. If force is true, the file is read even
+// if it didn't change, which is useful when the server rejected the token.
+func (f *fileToken) Token(force bool) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+	if !force && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	bytes, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+	token := strings.TrimSpace(string(bytes))
+	if token == "" {
+		return "", errors.New("token file is empty")
+	}
+
+	f.token = token
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	return f.token, nil
+}
+
+// cached returns the token read last, without checking the file.
+func (f *fileToken) cached() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.token
+}
+
+// tokenTransport adds the token from a file to every request. If the server
+// responds with 401, the file is read again and, if the token changed, the
+// request is retried once with the new token.
+type tokenTransport struct {
+	base  http.RoundTripper
+	token *fileToken
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.token.Token(false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(withToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// the body can't be sent again
+		return resp, nil
+	}
+
+	newToken, err := t.token.Token(true)
+	if err != nil || newToken == token {
+		return resp, nil //nolint:nilerr // return the original response, the token didn't change
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	retry := withToken(req, newToken)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("error getting request body: %w", err)
+		}
+	}
+
+	return t.base.RoundTrip(retry)
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
@@ -0,0 +1,118 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTPConfig configures the HTTP client used for the REST API.
+type HTTPConfig struct {
+	// Timeout limits the time of a request, including reading the response.
+	// Zero means defaultTimeout.
+	Timeout time.Duration
+	// ConnectTimeout limits the time to establish a connection. Zero means
+	// defaultConnectTimeout.
+	ConnectTimeout time.Duration
+	// ProxyURL is the URL of the proxy requests are sent through. If empty,
+	// the proxy is read from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables.
+	ProxyURL string
+	// MaxIdleConnsPerHost is the number of idle connections kept open. Zero
+	// means http.DefaultMaxIdleConnsPerHost.
+	MaxIdleConnsPerHost int
+	// Gzip compresses request bodies.
+	Gzip bool
+}
+
+// newHTTPClient returns an HTTP client configured with the given options. Its
+// transport uses tlsConfig for HTTPS connections.
+func (h HTTPConfig) newHTTPClient(tlsConfig *tls.Config) (*http.Client, error) {
+	if h.Timeout == 0 {
+		h.Timeout = defaultTimeout
+	}
+	if h.ConnectTimeout == 0 {
+		h.ConnectTimeout = defaultConnectTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConnsPerHost = h.MaxIdleConnsPerHost
+	transport.DialContext = (&net.Dialer{
+		Timeout:   h.ConnectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+
+	if h.ProxyURL != "" {
+		proxy, err := url.Parse(h.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	var rt http.RoundTripper = &headerTransport{base: transport}
+	if h.Gzip {
+		rt = &gzipTransport{base: rt}
+	}
+
+	return &http.Client{Transport: rt, Timeout: h.Timeout}, nil
+}
+
+// gzipTransport compresses request bodies using gzip.
+type gzipTransport struct {
+	base http.RoundTripper
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, fmt.Errorf("error compressing request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing request body: %w", err)
+	}
+	compressed := buf.Bytes()
+
+	// RoundTrip must not modify the original request
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,143 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/matryer/is"
+)
+
+func TestClient_HTTP_Timeout(t *testing.T) {
+	is := is.New(t)
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	client := &weaviate.Client{}
+	err := client.Open(weaviate.Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Scheme:   "http",
+		HTTP:     weaviate.HTTPConfig{Timeout: 50 * time.Millisecond},
+	})
+	is.NoErr(err)
+
+	start := time.Now()
+	_, err = client.Meta(context.Background())
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "Client.Timeout exceeded"))
+	is.True(time.Since(start) < 5*time.Second)
+}
+
+func TestClient_HTTP_Proxy(t *testing.T) {
+	is := is.New(t)
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	client := &weaviate.Client{}
+	err := client.Open(weaviate.Config{
+		Endpoint: "weaviate.test:8080",
+		Scheme:   "http",
+		HTTP:     weaviate.HTTPConfig{ProxyURL: proxy.URL},
+	})
+	is.NoErr(err)
+
+	meta, err := client.Meta(context.Background())
+	is.NoErr(err)
+	is.Equal(meta.Version, "1.27.0")
+	is.True(len(proxied) > 0)
+	for _, u := range proxied {
+		is.Equal(u, "http://weaviate.test:8080/v1/meta")
+	}
+}
+
+func TestClient_HTTP_Gzip(t *testing.T) {
+	testCases := []struct {
+		name         string
+		gzip         bool
+		wantEncoding string
+	}{
+		{name: "uncompressed"},
+		{name: "gzip", gzip: true, wantEncoding: "gzip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var gotEncoding string
+			var gotObject map[string]any
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotEncoding = r.Header.Get("Content-Encoding")
+
+				var body io.Reader = r.Body
+				if gotEncoding == "gzip" {
+					zr, err := gzip.NewReader(r.Body)
+					if err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					body = zr
+				}
+				if err := json.NewDecoder(body).Decode(&gotObject); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &weaviate.Client{}
+			err := client.Open(weaviate.Config{
+				Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+				Scheme:   "http",
+				HTTP:     weaviate.HTTPConfig{Gzip: tc.gzip},
+			})
+			is.NoErr(err)
+
+			err = client.Insert(context.Background(), &weaviate.Object{
+				ID:         "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+				Class:      "TestClass",
+				Properties: map[string]any{"title": "compressed"},
+			})
+			is.NoErr(err)
+
+			is.Equal(gotEncoding, tc.wantEncoding)
+			is.Equal(gotObject["class"], "TestClass")
+			is.Equal(gotObject["properties"], map[string]any{"title": "compressed"})
+		})
+	}
+}
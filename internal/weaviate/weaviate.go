@@ -0,0 +1,726 @@
+// Copyright © 2022 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/config"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/go-openapi/strfmt"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/data/replication"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+	"github.com/weaviate/weaviate/entities/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultTimeout is the timeout used by the Weaviate client by default.
+	defaultTimeout = 60 * time.Second
+	// defaultConnectTimeout is the dial timeout of http.DefaultTransport.
+	defaultConnectTimeout = 30 * time.Second
+)
+
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// ErrUnauthorized is returned when Weaviate rejected the credentials
+// used by the client.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrRateLimited is returned when Weaviate, or a module called by Weaviate
+// (e.g. a vectorizer), rejected a request because of rate limiting.
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrNotFound is returned by Merge if the object doesn't exist.
+var ErrNotFound = errors.New("object not found")
+
+type Config struct {
+	// AuthMechanism selects which of the credentials below are used.
+	AuthMechanism string
+	APIKey        string
+	// File containing the API key, used if APIKey is empty.
+	APIKeyFile        string
+	WCSAuth           WCSAuth
+	ClientCredentials ClientCredentials
+	BearerToken       BearerToken
+
+	Endpoint  string
+	Scheme    string
+	Headers   map[string]string
+	Transport string
+	GRPC      GRPCConfig
+	TLS       TLSConfig
+	HTTP      HTTPConfig
+}
+
+// NewConfig returns the configuration for connecting to the Weaviate instance
+// configured in c, with the configured credentials.
+func NewConfig(c config.Config) (Config, error) {
+	u, err := c.EndpointURL()
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg := Config{
+		Endpoint:      u.Host + u.Path,
+		Scheme:        u.Scheme,
+		AuthMechanism: c.Auth.Mechanism,
+		TLS: TLSConfig{
+			CAFile:             c.TLS.CAFile,
+			CertFile:           c.TLS.CertFile,
+			KeyFile:            c.TLS.KeyFile,
+			ServerName:         c.TLS.ServerName,
+			InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+		},
+		HTTP: HTTPConfig{
+			Timeout:             c.HTTP.Timeout,
+			ConnectTimeout:      c.HTTP.ConnectTimeout,
+			ProxyURL:            c.HTTP.ProxyURL,
+			MaxIdleConnsPerHost: c.HTTP.MaxIdleConnsPerHost,
+			Gzip:                c.HTTP.Gzip,
+		},
+	}
+
+	switch c.Auth.Mechanism {
+	case config.AuthMechanismAPIKey:
+		cfg.APIKey = c.Auth.APIKey
+		cfg.APIKeyFile = c.Auth.APIKeyFile
+	case config.AuthMechanismWCSCreds:
+		cfg.WCSAuth = WCSAuth{
+			Username: c.Auth.WCSCredentials.Username,
+			Password: c.Auth.WCSCredentials.Password,
+		}
+	case config.AuthMechanismOIDCClientCredentials:
+		cc := c.Auth.ClientCredentials
+		cfg.ClientCredentials = ClientCredentials{
+			ClientID:     cc.ClientID,
+			ClientSecret: cc.ClientSecret,
+			Scopes:       cc.Scopes,
+			TokenURL:     cc.TokenURL,
+		}
+	case config.AuthMechanismBearerToken:
+		cfg.BearerToken = BearerToken{
+			Token: c.Auth.BearerToken.Token,
+			File:  c.Auth.BearerToken.File,
+		}
+	}
+
+	return cfg, nil
+}
+
+type GRPCConfig struct {
+	// Address of the gRPC API in the form host:port.
+	Address string
+	Secure  bool
+}
+
+type WCSAuth struct {
+	Username string
+	Password string
+}
+
+type ClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	TokenURL     string
+}
+
+type BearerToken struct {
+	Token string
+	// File containing the token, used if Token is empty.
+	File string
+}
+
+type Object struct {
+	ID         string
+	Class      string
+	Properties map[string]interface{}
+	Vector     []float32
+	// Vectors contains the named vectors of the object.
+	Vectors map[string][]float32
+	// Tenant is the tenant of the object, if the class is multi-tenant.
+	Tenant string
+
+	// CreationTimeUnix and LastUpdateTimeUnix are the times (in Unix
+	// milliseconds) the object was created and last updated. They are only
+	// set on objects read from Weaviate.
+	CreationTimeUnix   int64
+	LastUpdateTimeUnix int64
+}
+
+// Class is the definition of a class.
+type Class struct {
+	Name       string
+	Properties []Property
+	// IndexTimestamps is true if the creation and update times of objects
+	// are indexed, which is required for filtering and sorting by them.
+	IndexTimestamps bool
+	// MultiTenancy is true if the class is multi-tenant.
+	MultiTenancy bool
+}
+
+// Property is a property of a class, or a nested property of an object
+// property.
+type Property struct {
+	Name string
+	// DataType contains the data type of the property, or the classes
+	// referenced by a cross-reference property.
+	DataType         []string
+	NestedProperties []Property
+}
+
+// Meta contains information about a Weaviate instance.
+type Meta struct {
+	Version string
+	// Names of the enabled modules.
+	Modules []string
+}
+
+type Client struct {
+	client *weaviate.Client
+	grpc   *grpcClient
+
+	// secrets are removed from the messages of returned errors.
+	secrets []string
+	token   tokenSource
+}
+
+func (c *Client) Open(config Config) (err error) {
+	c.secrets = configSecrets(config)
+	defer c.redact(context.Background(), &err)
+
+	headers := make(map[string]string, len(config.Headers))
+	for k, v := range config.Headers {
+		headers[k] = v
+	}
+
+	tlsConfig, err := config.TLS.tlsConfig()
+	if err != nil {
+		return fmt.Errorf("error configuring TLS: %w", err)
+	}
+
+	httpClient, err := config.HTTP.newHTTPClient(tlsConfig)
+	if err != nil {
+		return fmt.Errorf("error configuring HTTP client: %w", err)
+	}
+
+	httpClient, token, err := configureAuth(config, httpClient, headers)
+	if err != nil {
+		return fmt.Errorf("error configuring authentication: %w", err)
+	}
+	c.token = token
+
+	wcfg := weaviate.Config{
+		Host:             config.Endpoint,
+		Scheme:           config.Scheme,
+		Headers:          headers,
+		ConnectionClient: httpClient,
+	}
+
+	client, err := weaviate.NewClient(wcfg)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	c.client = client
+
+	if config.Transport == TransportGRPC {
+		var grpcErr error
+		c.grpc, grpcErr = newGRPCClient(config.GRPC, tlsConfig, headers, token, c.serverVersion)
+		if grpcErr != nil {
+			c.redact(context.Background(), &grpcErr)
+			sdk.Logger(context.Background()).Warn().
+				Err(grpcErr).
+				Str("address", config.GRPC.Address).
+				Msg("gRPC API unavailable, falling back to HTTP")
+		}
+	}
+
+	return nil
+}
+
+// Close releases the resources held by the client.
+func (c *Client) Close() error {
+	if c.grpc == nil {
+		return nil
+	}
+
+	return c.grpc.Close()
+}
+
+func (c *Client) serverVersion() string {
+	meta, err := c.client.Misc().MetaGetter().Do(context.Background())
+	if err != nil {
+		return ""
+	}
+
+	return meta.Version
+}
+
+// Live returns true if the Weaviate instance is live.
+func (c *Client) Live(ctx context.Context) (_ bool, err error) {
+	defer c.redact(ctx, &err)
+
+	live, err := c.client.Misc().LiveChecker().Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking liveness: %w", err)
+	}
+
+	return live, nil
+}
+
+// Ready returns true if the Weaviate instance is ready to serve requests.
+func (c *Client) Ready(ctx context.Context) (_ bool, err error) {
+	defer c.redact(ctx, &err)
+
+	ready, err := c.client.Misc().ReadyChecker().Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking readiness: %w", err)
+	}
+
+	return ready, nil
+}
+
+// Meta returns the version and the enabled modules of the Weaviate instance.
+// Getting the metadata requires authentication (unless anonymous access is
+// enabled), so ErrUnauthorized is returned if the credentials are rejected.
+func (c *Client) Meta(ctx context.Context) (_ *Meta, err error) {
+	defer c.redact(ctx, &err)
+
+	meta, err := c.client.Misc().MetaGetter().Do(ctx)
+	if err != nil {
+		var wErr *fault.WeaviateClientError
+		if errors.As(err, &wErr) &&
+			(wErr.StatusCode == http.StatusUnauthorized || wErr.StatusCode == http.StatusForbidden) {
+			err = fmt.Errorf("%w: %w", ErrUnauthorized, err)
+		}
+		return nil, fmt.Errorf("error getting metadata: %w", err)
+	}
+
+	var modules []string
+	if m, ok := meta.Modules.(map[string]interface{}); ok {
+		for name := range m {
+			modules = append(modules, name)
+		}
+	}
+	sort.Strings(modules)
+
+	return &Meta{
+		Version: meta.Version,
+		Modules: modules,
+	}, nil
+}
+
+// ClassExists returns true if the class is defined in the schema.
+func (c *Client) ClassExists(ctx context.Context, class string) (_ bool, err error) {
+	defer c.redact(ctx, &err)
+
+	exists, err := c.client.Schema().ClassExistenceChecker().
+		WithClassName(class).
+		Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking class: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Class returns the definition of the class.
+func (c *Client) Class(ctx context.Context, class string) (_ *Class, err error) {
+	defer c.redact(ctx, &err)
+
+	cls, err := c.client.Schema().ClassGetter().WithClassName(class).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class: %w", err)
+	}
+
+	properties := make([]Property, len(cls.Properties))
+	for i, p := range cls.Properties {
+		properties[i] = Property{
+			Name:             p.Name,
+			DataType:         p.DataType,
+			NestedProperties: newNestedProperties(p.NestedProperties),
+		}
+	}
+
+	return &Class{
+		Name:            cls.Class,
+		Properties:      properties,
+		IndexTimestamps: cls.InvertedIndexConfig != nil && cls.InvertedIndexConfig.IndexTimestamps,
+		MultiTenancy:    cls.MultiTenancyConfig != nil && cls.MultiTenancyConfig.Enabled,
+	}, nil
+}
+
+// Tenants returns the names of the tenants of the class that can be read, in
+// ascending order. Tenants that are offloaded or inactive are left out.
+func (c *Client) Tenants(ctx context.Context, class string) (_ []string, err error) {
+	defer c.redact(ctx, &err)
+
+	tenants, err := c.client.Schema().TenantsGetter().WithClassName(class).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tenants: %w", classifyError(err))
+	}
+
+	var names []string
+	for _, t := range tenants {
+		switch t.ActivityStatus {
+		case "", models.TenantActivityStatusHOT, models.TenantActivityStatusACTIVE:
+			names = append(names, t.Name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func newNestedProperties(nested []*models.NestedProperty) []Property {
+	if len(nested) == 0 {
+		return nil
+	}
+
+	properties := make([]Property, len(nested))
+	for i, p := range nested {
+		properties[i] = Property{
+			Name:             p.Name,
+			DataType:         p.DataType,
+			NestedProperties: newNestedProperties(p.NestedProperties),
+		}
+	}
+	return properties
+}
+
+// CreateClass creates a class with the default settings of the
+// Weaviate instance (e.g. the default vectorizer).
+func (c *Client) CreateClass(ctx context.Context, class string) (err error) {
+	defer c.redact(ctx, &err)
+
+	err = c.client.Schema().ClassCreator().
+		WithClass(&models.Class{Class: class}).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating class: %w", err)
+	}
+
+	return nil
+}
+
+// Insert creates obj. If an object with the same ID exists, an error is
+// returned, unless the gRPC API is used, which replaces existing objects. Use
+// Upsert to replace existing objects regardless of the transport.
+func (c *Client) Insert(ctx context.Context, obj *Object) (err error) {
+	defer c.redact(ctx, &err)
+
+	if c.grpc != nil {
+		err := c.grpc.BatchObjects(ctx, obj)
+		if err == nil {
+			return nil
+		}
+		if !isUnavailable(err) {
+			return fmt.Errorf("error creating object: %w", classifyError(err))
+		}
+		c.logFallback(ctx, err)
+	}
+
+	err = c.create(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("error creating object: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+// Update replaces the object with obj. An object which doesn't exist is
+// created, so that writing a record again, e.g. an update followed by a
+// delete, doesn't fail.
+func (c *Client) Update(ctx context.Context, obj *Object) (err error) {
+	defer c.redact(ctx, &err)
+
+	// Objects written using the gRPC API replace existing objects,
+	// which is the same as the HTTP update below.
+	if c.grpc != nil {
+		err := c.grpc.BatchObjects(ctx, obj)
+		if err == nil {
+			return nil
+		}
+		if !isUnavailable(err) {
+			return fmt.Errorf("error update object: %w", classifyError(err))
+		}
+		c.logFallback(ctx, err)
+	}
+
+	err = c.replace(ctx, obj)
+	if notFound(err) {
+		err = c.create(ctx, obj)
+	}
+	if err != nil {
+		return fmt.Errorf("error update object: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+// create creates obj using the REST API.
+func (c *Client) create(ctx context.Context, obj *Object) error {
+	_, err := c.client.Data().Creator().
+		WithClassName(obj.Class).
+		WithID(obj.ID).
+		WithProperties(obj.Properties).
+		WithVector(obj.Vector).
+		WithVectors(obj.modelVectors()).
+		WithTenant(obj.Tenant).
+		WithConsistencyLevel(replication.ConsistencyLevel.ALL).
+		Do(ctx)
+	return err
+}
+
+// replace replaces the object with obj using the REST API.
+func (c *Client) replace(ctx context.Context, obj *Object) error {
+	return c.client.Data().Updater().
+		WithID(obj.ID).
+		WithClassName(obj.Class).
+		WithProperties(obj.Properties).
+		WithVector(obj.Vector).
+		WithVectors(obj.modelVectors()).
+		WithTenant(obj.Tenant).
+		WithConsistencyLevel(replication.ConsistencyLevel.ALL).
+		Do(ctx)
+}
+
+// notFound returns true if err is the error returned by the REST API when
+// the object doesn't exist.
+func notFound(err error) bool {
+	var wErr *fault.WeaviateClientError
+	return errors.As(err, &wErr) && wErr.StatusCode == http.StatusNotFound
+}
+
+// Merge sets the properties of obj on the existing object, keeping its other
+// properties and its vectors. This always uses the REST API, as the gRPC API
+// doesn't support partial updates.
+func (c *Client) Merge(ctx context.Context, obj *Object) (err error) {
+	defer c.redact(ctx, &err)
+
+	err = c.client.Data().Updater().
+		WithMerge().
+		WithID(obj.ID).
+		WithClassName(obj.Class).
+		WithProperties(obj.Properties).
+		WithTenant(obj.Tenant).
+		WithConsistencyLevel(replication.ConsistencyLevel.ALL).
+		Do(ctx)
+	if notFound(err) {
+		return fmt.Errorf("error merging object: %w", ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("error merging object: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+func (c *Client) Delete(ctx context.Context, obj *Object) (err error) {
+	defer c.redact(ctx, &err)
+
+	if c.grpc != nil {
+		err := c.grpc.BatchDelete(ctx, obj)
+		if err == nil {
+			return nil
+		}
+		if !isUnavailable(err) {
+			return fmt.Errorf("error deleting object: %w", classifyError(err))
+		}
+		c.logFallback(ctx, err)
+	}
+
+	err = c.client.Data().Deleter().
+		WithClassName(obj.Class).
+		WithID(obj.ID).
+		WithTenant(obj.Tenant).
+		WithConsistencyLevel(replication.ConsistencyLevel.ALL).
+		Do(ctx)
+	// like with the gRPC API, deleting an object which doesn't exist, e.g.
+	// when a delete is written again, succeeds
+	if err != nil && !notFound(err) {
+		return fmt.Errorf("error deleting object: %w", classifyError(err))
+	}
+
+	return nil
+}
+
+// Upsert writes objects using a batch request. Existing objects with the same
+// ID are replaced.
+func (c *Client) Upsert(ctx context.Context, objs []*Object) (err error) {
+	defer c.redact(ctx, &err)
+
+	if c.grpc != nil {
+		err := c.grpc.BatchObjects(ctx, objs...)
+		if err == nil {
+			return nil
+		}
+		if !isUnavailable(err) {
+			return fmt.Errorf("error writing objects: %w", classifyError(err))
+		}
+		c.logFallback(ctx, err)
+	}
+
+	objects := make([]*models.Object, len(objs))
+	for i, obj := range objs {
+		objects[i] = obj.model()
+	}
+	resp, err := c.client.Batch().ObjectsBatcher().
+		WithObjects(objects...).
+		WithConsistencyLevel(replication.ConsistencyLevel.ALL).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error writing objects: %w", classifyError(err))
+	}
+	for _, r := range resp {
+		if r.Result != nil && r.Result.Errors != nil && len(r.Result.Errors.Error) > 0 {
+			return fmt.Errorf("error writing object %v: %v", r.ID, r.Result.Errors.Error[0].Message)
+		}
+	}
+
+	return nil
+}
+
+// DeleteWhere deletes the objects of the class and tenant matching where.
+func (c *Client) DeleteWhere(ctx context.Context, class, tenant string, where *filters.WhereBuilder) (err error) {
+	defer c.redact(ctx, &err)
+
+	resp, err := c.client.Batch().ObjectsBatchDeleter().
+		WithClassName(class).
+		WithTenant(tenant).
+		WithWhere(where).
+		WithConsistencyLevel(replication.ConsistencyLevel.ALL).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting objects: %w", classifyError(err))
+	}
+	if resp.Results != nil && resp.Results.Failed > 0 {
+		return fmt.Errorf("error deleting objects: %v objects failed to be deleted", resp.Results.Failed)
+	}
+
+	return nil
+}
+
+// classifyError wraps err with ErrRateLimited if it was caused by rate
+// limiting. Vectorizer modules don't forward the status code they got from
+// their provider, so the error message is checked too.
+func classifyError(err error) error {
+	var wErr *fault.WeaviateClientError
+	rateLimited := status.Code(err) == codes.ResourceExhausted ||
+		(errors.As(err, &wErr) && wErr.StatusCode == http.StatusTooManyRequests)
+	if !rateLimited {
+		msg := strings.ToLower(err.Error())
+		rateLimited = strings.Contains(msg, "rate limit") ||
+			strings.Contains(msg, "too many requests") ||
+			strings.Contains(msg, "status code: 429")
+	}
+
+	if rateLimited {
+		return fmt.Errorf("%w: %w", ErrRateLimited, err)
+	}
+
+	return err
+}
+
+// Objects returns up to limit objects of the class and tenant with an ID
+// greater than after, ordered by ID. An empty after returns the first objects.
+// The tenant is empty for classes without multi-tenancy.
+// This uses Weaviate's cursor API, which is consistent even if objects are
+// written concurrently.
+func (c *Client) Objects(ctx context.Context, class, tenant, after string, limit int) (_ []*Object, err error) {
+	defer c.redact(ctx, &err)
+
+	objects, err := c.client.Data().ObjectsGetter().
+		WithClassName(class).
+		WithTenant(tenant).
+		WithAfter(after).
+		WithLimit(limit).
+		WithVector().
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting objects: %w", classifyError(err))
+	}
+
+	out := make([]*Object, len(objects))
+	for i, o := range objects {
+		out[i] = newObject(o)
+	}
+
+	return out, nil
+}
+
+// newObject converts an object returned by Weaviate.
+func newObject(o *models.Object) *Object {
+	properties, _ := o.Properties.(map[string]interface{})
+
+	var vectors map[string][]float32
+	if len(o.Vectors) > 0 {
+		vectors = make(map[string][]float32, len(o.Vectors))
+		for name, v := range o.Vectors {
+			vectors[name] = v
+		}
+	}
+
+	return &Object{
+		ID:                 o.ID.String(),
+		Class:              o.Class,
+		Properties:         properties,
+		Vector:             o.Vector,
+		Vectors:            vectors,
+		Tenant:             o.Tenant,
+		CreationTimeUnix:   o.CreationTimeUnix,
+		LastUpdateTimeUnix: o.LastUpdateTimeUnix,
+	}
+}
+
+// model returns the object as written by batch requests.
+func (o *Object) model() *models.Object {
+	return &models.Object{
+		ID:         strfmt.UUID(o.ID),
+		Class:      o.Class,
+		Properties: o.Properties,
+		Vector:     o.Vector,
+		Vectors:    o.modelVectors(),
+		Tenant:     o.Tenant,
+	}
+}
+
+// modelVectors returns the named vectors of the object, or nil if it has
+// none.
+func (o *Object) modelVectors() models.Vectors {
+	if len(o.Vectors) == 0 {
+		return nil
+	}
+
+	vectors := make(models.Vectors, len(o.Vectors))
+	for name, v := range o.Vectors {
+		vectors[name] = v
+	}
+	return vectors
+}
@@ -0,0 +1,508 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviate_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/matryer/is"
+	pb "github.com/weaviate/weaviate/grpc/generated/protocol/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// restServer is a stand-in for Weaviate's REST API, recording the requests
+// it receives.
+type restServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func newRESTServer(t *testing.T) *restServer {
+	s := &restServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/meta":
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+			return
+		case "/v1/.well-known/openid-configuration":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *restServer) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// grpcServer is an in-process stand-in for Weaviate's gRPC API.
+type grpcServer struct {
+	pb.UnimplementedWeaviateServer
+
+	addr string
+
+	mu      sync.Mutex
+	batches []*pb.BatchObjectsRequest
+	deletes []*pb.BatchDeleteRequest
+	auth    []string
+	// openAIKeys contains the X-OpenAI-Api-Key headers of batch requests.
+	openAIKeys []string
+}
+
+func newGRPCServer(t *testing.T) *grpcServer {
+	is := is.New(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	is.NoErr(err)
+
+	s := &grpcServer{addr: lis.Addr().String()}
+	srv := grpc.NewServer()
+	pb.RegisterWeaviateServer(srv, s)
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return s
+}
+
+func (s *grpcServer) BatchObjects(ctx context.Context, req *pb.BatchObjectsRequest) (*pb.BatchObjectsReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.auth = append(s.auth, md.Get("authorization")...)
+	s.openAIKeys = append(s.openAIKeys, md.Get("x-openai-api-key")...)
+	s.batches = append(s.batches, req)
+
+	return &pb.BatchObjectsReply{}, nil
+}
+
+func (s *grpcServer) BatchDelete(_ context.Context, req *pb.BatchDeleteRequest) (*pb.BatchDeleteReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deletes = append(s.deletes, req)
+
+	return &pb.BatchDeleteReply{Matches: 1, Successful: 1}, nil
+}
+
+func TestClient_GRPC(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	rest := newRESTServer(t)
+	grpcSrv := newGRPCServer(t)
+
+	client := &weaviate.Client{}
+	err := client.Open(weaviate.Config{
+		AuthMechanism: "apiKey",
+		APIKey:        "test-api-key",
+		Endpoint:      strings.TrimPrefix(rest.URL, "http://"),
+		Scheme:        "http",
+		Transport:     weaviate.TransportGRPC,
+		GRPC:          weaviate.GRPCConfig{Address: grpcSrv.addr},
+	})
+	is.NoErr(err)
+	defer client.Close()
+
+	obj := &weaviate.Object{
+		ID:         "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		Class:      "TestClass",
+		Properties: map[string]interface{}{"name": "computer"},
+		Vector:     []float32{1, 2},
+	}
+	is.NoErr(client.Insert(ctx, obj))
+	is.NoErr(client.Update(ctx, obj))
+	is.NoErr(client.Delete(ctx, obj))
+
+	is.Equal(len(rest.Requests()), 0) // expected all writes to use gRPC
+
+	grpcSrv.mu.Lock()
+	defer grpcSrv.mu.Unlock()
+
+	is.Equal(len(grpcSrv.batches), 2)
+	got := grpcSrv.batches[0].GetObjects()[0]
+	is.Equal(got.GetUuid(), obj.ID)
+	is.Equal(got.GetCollection(), obj.Class)
+	is.Equal(got.GetProperties().GetNonRefProperties().AsMap(), obj.Properties)
+	is.Equal(grpcSrv.auth, []string{"Bearer test-api-key", "Bearer test-api-key"})
+
+	is.Equal(len(grpcSrv.deletes), 1)
+	is.Equal(grpcSrv.deletes[0].GetCollection(), obj.Class)
+	is.Equal(grpcSrv.deletes[0].GetFilters().GetValueText(), obj.ID)
+}
+
+func TestClient_GRPC_FallbackToHTTP(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	rest := newRESTServer(t)
+
+	// reserve an address nobody is listening on
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	is.NoErr(err)
+	addr := lis.Addr().String()
+	is.NoErr(lis.Close())
+
+	client := &weaviate.Client{}
+	err = client.Open(weaviate.Config{
+		Endpoint:  strings.TrimPrefix(rest.URL, "http://"),
+		Scheme:    "http",
+		Transport: weaviate.TransportGRPC,
+		GRPC:      weaviate.GRPCConfig{Address: addr},
+	})
+	is.NoErr(err)
+	defer client.Close()
+
+	obj := &weaviate.Object{
+		ID:         "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		Class:      "TestClass",
+		Properties: map[string]interface{}{"name": "computer"},
+	}
+	is.NoErr(client.Insert(ctx, obj))
+	is.NoErr(client.Delete(ctx, obj))
+
+	is.Equal(rest.Requests(), []string{
+		"POST /v1/objects",
+		"DELETE /v1/objects/TestClass/f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+	})
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/meta":
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+		case "/v1/.well-known/openid-configuration":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"error": []map[string]string{{
+					"message": "update vector: connection to: OpenAI API failed with status: 429 error: Rate limit reached",
+				}},
+			})
+		}
+	}))
+	defer srv.Close()
+
+	client := &weaviate.Client{}
+	is.NoErr(client.Open(weaviate.Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Scheme:   "http",
+	}))
+
+	err := client.Insert(ctx, &weaviate.Object{
+		ID:    "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		Class: "TestClass",
+	})
+	is.True(err != nil)
+	is.True(errors.Is(err, weaviate.ErrRateLimited))
+}
+
+func TestClient_PathPrefix(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &weaviate.Client{}
+	err := client.Open(weaviate.Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://") + "/weaviate",
+		Scheme:   "http",
+	})
+	is.NoErr(err)
+
+	err = client.Insert(ctx, &weaviate.Object{
+		ID:    "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		Class: "TestClass",
+	})
+	is.NoErr(err)
+
+	is.True(len(paths) > 0)
+	is.Equal(paths[len(paths)-1], "POST /weaviate/v1/objects")
+}
+
+func TestClient_ContextHeaders(t *testing.T) {
+	is := is.New(t)
+
+	var mu sync.Mutex
+	var restKeys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/objects" {
+			mu.Lock()
+			restKeys = append(restKeys, r.Header.Get("X-OpenAI-Api-Key"))
+			mu.Unlock()
+		}
+		_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	grpcSrv := newGRPCServer(t)
+
+	for _, transport := range []string{weaviate.TransportHTTP, weaviate.TransportGRPC} {
+		client := &weaviate.Client{}
+		err := client.Open(weaviate.Config{
+			Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+			Scheme:    "http",
+			Headers:   map[string]string{"X-OpenAI-Api-Key": "default-key"},
+			Transport: transport,
+			GRPC:      weaviate.GRPCConfig{Address: grpcSrv.addr},
+		})
+		is.NoErr(err)
+
+		obj := &weaviate.Object{
+			ID:    "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+			Class: "TestClass",
+		}
+		ctx := weaviate.WithHeaders(context.Background(), map[string]string{"X-OpenAI-Api-Key": "customer-key"})
+		is.NoErr(client.Insert(ctx, obj))
+		is.NoErr(client.Insert(context.Background(), obj))
+		is.NoErr(client.Close())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	is.Equal(restKeys, []string{"customer-key", "default-key"})
+
+	grpcSrv.mu.Lock()
+	defer grpcSrv.mu.Unlock()
+	is.Equal(grpcSrv.openAIKeys, []string{"customer-key", "default-key"})
+}
+
+func TestClient_TextProperties(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/graphql" {
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+			return
+		}
+		var req struct {
+			Query string `json:"query"`
+		}
+		is.NoErr(json.NewDecoder(r.Body).Decode(&req))
+		query = req.Query
+
+		_, _ = w.Write([]byte(`{"data":{"Get":{"TestClass":[` +
+			`{"hash":"abc","version":"2","_additional":{"id":"f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"}},` +
+			`{"hash":null,"version":"1","_additional":{"id":"53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e"}}]}}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &weaviate.Client{}
+	is.NoErr(client.Open(weaviate.Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Scheme:   "http",
+	}))
+
+	got, err := client.TextProperties(ctx, "TestClass", "tenantA", []string{"hash", "version"}, []string{
+		"f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		"53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e",
+	})
+	is.NoErr(err)
+	is.Equal(got, map[string]map[string]string{
+		"f9a510b3-5865-40e4-9fe8-e7fbab25b8bc": {"hash": "abc", "version": "2"},
+		"53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e": {"version": "1"},
+	})
+
+	is.True(strings.Contains(query, `tenant: "tenantA"`))
+	is.True(strings.Contains(query, `operator: ContainsAny path: ["id"] valueText: ["f9a510b3-5865-40e4-9fe8-e7fbab25b8bc","53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e"]`))
+	is.True(strings.Contains(query, `limit: 2`))
+	is.True(strings.Contains(query, `hash version _additional{id}`))
+}
+
+func TestClient_RetriedWrites(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	// the object was written by a previous attempt and deleted afterwards
+	exists := false
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method != http.MethodPost && !exists:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &weaviate.Client{}
+	is.NoErr(client.Open(weaviate.Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Scheme:   "http",
+	}))
+	obj := &weaviate.Object{
+		ID:         "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		Class:      "TestClass",
+		Properties: map[string]any{"name": "a"},
+	}
+	const path = "/v1/objects/TestClass/f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"
+
+	// a missing object is created by an update and ignored by a delete
+	is.NoErr(client.Update(ctx, obj))
+	is.NoErr(client.Delete(ctx, obj))
+	is.Equal(requests, []string{"PUT " + path, "POST /v1/objects", "DELETE " + path})
+}
+
+func TestClient_Insert_UnprocessableEntity(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+	}{
+		{
+			name:    "object exists",
+			message: "id 'f9a510b3-5865-40e4-9fe8-e7fbab25b8bc' already exists",
+		},
+		{
+			name:    "invalid object",
+			message: "invalid object: no such prop with name 'unknown' found in class 'TestClass'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			var requests []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+					return
+				}
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"error":[{"message":"` + tc.message + `"}]}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			client := &weaviate.Client{}
+			is.NoErr(client.Open(weaviate.Config{
+				Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+				Scheme:   "http",
+			}))
+
+			err := client.Insert(ctx, &weaviate.Object{
+				ID:         "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+				Class:      "TestClass",
+				Properties: map[string]any{"unknown": "a"},
+			})
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.message))
+			is.Equal(requests, []string{"POST /v1/objects"}) // expected the object not to be replaced
+		})
+	}
+}
+
+func TestClient_Merge(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var (
+		requests []string
+		bodies   []map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]any
+		is.NoErr(json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+
+		if strings.HasSuffix(r.URL.Path, "/53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &weaviate.Client{}
+	is.NoErr(client.Open(weaviate.Config{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Scheme:   "http",
+	}))
+
+	err := client.Merge(ctx, &weaviate.Object{
+		ID:         "f9a510b3-5865-40e4-9fe8-e7fbab25b8bc",
+		Class:      "TestClass",
+		Properties: map[string]any{"deleted": true},
+		Tenant:     "tenantA",
+	})
+	is.NoErr(err)
+	is.Equal(requests, []string{"PATCH /v1/objects/TestClass/f9a510b3-5865-40e4-9fe8-e7fbab25b8bc"})
+	is.Equal(bodies[0]["properties"], map[string]any{"deleted": true})
+	is.Equal(bodies[0]["tenant"], "tenantA")
+
+	err = client.Merge(ctx, &weaviate.Object{
+		ID:    "53a4b4d3-d9c1-4b5a-8d0b-6f1a0f2f8c4e",
+		Class: "TestClass",
+	})
+	is.True(errors.Is(err, weaviate.ErrNotFound))
+}
@@ -0,0 +1,156 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// initCursors sets the cursors of collections without one. During the
+// snapshot, a cursor points to the object updated last before the snapshot
+// started, so that changes made while the snapshot is running are read
+// afterwards. In CDC mode, a collection without a cursor was added to the
+// configuration later and is read from the start.
+func (s *Source) initCursors(ctx context.Context) error {
+	for _, c := range s.collections {
+		if _, ok := s.position.Cursors[c.key()]; ok {
+			continue
+		}
+
+		var cursor Cursor
+		if s.position.Mode == ModeSnapshot {
+			last, err := s.client.LastUpdatedObject(ctx, c.class, c.tenant, s.wheres[c.class])
+			if err != nil {
+				return fmt.Errorf("error getting last update of class %v: %w", c.class, err)
+			}
+			if last != nil {
+				cursor = Cursor{Time: last.LastUpdateTimeUnix, ID: last.ID}
+			}
+		}
+		s.position = s.position.withCursor(c, cursor)
+	}
+
+	return nil
+}
+
+// readChanges returns up to n records for objects created or updated after
+// the cursors. Classes are polled in turns; sdk.ErrBackoffRetry is returned
+// once none of them has changes.
+func (s *Source) readChanges(ctx context.Context, n int) ([]opencdc.Record, error) {
+	var records []opencdc.Record
+	for len(records) < n {
+		for len(s.buffer) == 0 {
+			if s.idle == len(s.collections) {
+				s.idle = 0
+				if len(records) > 0 {
+					return records, nil
+				}
+				return nil, sdk.ErrBackoffRetry
+			}
+
+			err := s.fetchChanges(ctx)
+			if err != nil {
+				if len(records) > 0 {
+					return records, nil
+				}
+				return nil, err
+			}
+		}
+
+		change := s.buffer[0]
+		c := collection{class: change.Class, tenant: change.Tenant}
+		obj, err := s.client.Object(ctx, c.class, c.tenant, change.ID)
+		if err != nil {
+			if len(records) > 0 {
+				return records, nil
+			}
+			return nil, fmt.Errorf("error reading object %v of class %v: %w", change.ID, change.Class, err)
+		}
+		s.buffer = s.buffer[1:]
+		s.position = s.position.withCursor(c, Cursor{Time: change.LastUpdateTimeUnix, ID: change.ID})
+
+		if obj == nil {
+			// the object was deleted since the change was fetched
+			continue
+		}
+
+		pos := s.position.ToSDKPosition()
+		metadata := recordMetadata(obj)
+		key := opencdc.RawData(obj.ID)
+		payload := opencdc.StructuredData(obj.Properties)
+		if s.deletes != nil {
+			s.deletes.emit(c, obj.ID)
+		}
+		var record opencdc.Record
+		if obj.CreationTimeUnix == obj.LastUpdateTimeUnix {
+			record = sdk.Util.Source.NewRecordCreate(pos, metadata, key, payload)
+		} else {
+			record = sdk.Util.Source.NewRecordUpdate(pos, metadata, key, nil, payload)
+		}
+		if s.schemas != nil {
+			err = s.attachSchema(ctx, change.Class, &record)
+			if err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// fetchChanges fills the buffer with the objects of the current class updated
+// after its cursor, ordered by update time and ID, and selects the next class.
+func (s *Source) fetchChanges(ctx context.Context) error {
+	c := s.collections[s.collection]
+	s.collection = (s.collection + 1) % len(s.collections)
+
+	objects, err := s.updatedObjects(ctx, c, s.position.Cursors[c.key()], nil)
+	if err != nil {
+		return fmt.Errorf("error reading changes of class %v: %w", c.class, err)
+	}
+	s.buffer = objects
+
+	if len(s.buffer) == 0 {
+		s.idle++
+	} else {
+		s.idle = 0
+	}
+
+	return nil
+}
+
+// updatedObjects returns the next page of objects of the collection updated
+// after the cursor, in order of update time. If end is not nil, objects
+// updated after end are left out.
+func (s *Source) updatedObjects(ctx context.Context, c collection, cursor Cursor, end *Cursor) ([]*weaviate.Object, error) {
+	objects, err := s.client.UpdatedObjects(ctx, c.class, c.tenant, s.wheres[c.class], cursor.Time, cursor.ID, s.config.FetchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, obj := range objects {
+		if end != nil && end.After(obj) {
+			return objects[:i], nil
+		}
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,158 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/config"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+type Config struct {
+	sdk.DefaultSourceMiddleware
+	config.Config
+
+	// Classes to read, in the given order.
+	Classes []string `json:"classes" validate:"required"`
+
+	// Filter the objects read must match, either conditions like
+	// `status == "published" and views >= 100` or a Weaviate where filter in
+	// JSON. Filtered classes are read in order of update time, which requires
+	// them to be configured with `invertedIndexConfig.indexTimestamps`
+	// enabled.
+	Where string `json:"where"`
+
+	// Tenants to read from classes with multi-tenancy enabled, or `*` for all
+	// tenants that are active when the source is opened. Each class and
+	// tenant is read with its own position. Ignored for classes without
+	// multi-tenancy.
+	Tenants []string `json:"tenants"`
+
+	// Number of objects fetched from Weaviate with a single request.
+	FetchSize int `json:"fetchSize" default:"100" validate:"greater-than=0"`
+
+	CDC CDCConfig `json:"cdc"`
+
+	Schema SchemaConfig `json:"schema"`
+}
+
+type SchemaConfig struct {
+	// Whether an Avro schema derived from the class definition should be
+	// attached to records, registered with the subject `<class>.payload`.
+	// If disabled, the schema is extracted from the payload if
+	// `sdk.schema.extract.payload.enabled` is true.
+	Enabled bool `json:"enabled" default:"true"`
+}
+
+type CDCConfig struct {
+	// Whether objects created or updated after the snapshot started should
+	// be read once the snapshot is completed. Changes are detected using the
+	// update time of objects, which requires the classes to be configured
+	// with `invertedIndexConfig.indexTimestamps` enabled.
+	Enabled bool `json:"enabled" default:"false"`
+
+	Deletes DeletesConfig `json:"deletes"`
+}
+
+type DeletesConfig struct {
+	// Whether deleted objects should be detected. Weaviate doesn't record
+	// deletes, so the IDs of all objects are periodically scanned and
+	// compared to the IDs found by the previous scan.
+	Enabled bool `json:"enabled"`
+	// Time between two scans of the IDs.
+	ScanInterval time.Duration `json:"scanInterval" default:"5m"`
+	// Directory the IDs found by the last scan are stored in. Required if
+	// deletes are detected. The directory must persist across restarts and
+	// must not be shared with other pipelines.
+	StateDir string `json:"stateDir"`
+	// Number of IDs fetched from Weaviate with a single request while
+	// scanning. IDs are compared and stored on disk in this many at a time,
+	// so this bounds the memory used by a scan.
+	FetchSize int `json:"fetchSize" default:"1000" validate:"greater-than=0"`
+}
+
+func (c *Config) Validate(ctx context.Context) error {
+	err := c.DefaultSourceMiddleware.Validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Config.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	seen := make(map[string]bool, len(c.Classes))
+	for _, class := range c.Classes {
+		if class == "" {
+			return errors.New("invalid configuration: classes must not be empty")
+		}
+		if seen[class] {
+			return fmt.Errorf("invalid configuration: class %v is listed more than once", class)
+		}
+		seen[class] = true
+	}
+
+	if c.Where != "" {
+		_, err = parseWhere(c.Where, nil)
+		if err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
+	seen = make(map[string]bool, len(c.Tenants))
+	for _, tenant := range c.Tenants {
+		switch {
+		case tenant == "":
+			return errors.New("invalid configuration: tenants must not be empty")
+		case tenant == "*" && len(c.Tenants) > 1:
+			return errors.New("invalid configuration: tenants can't contain * and other tenants")
+		case seen[tenant]:
+			return fmt.Errorf("invalid configuration: tenant %v is listed more than once", tenant)
+		}
+		seen[tenant] = true
+	}
+
+	if c.CDC.Deletes.Enabled {
+		switch {
+		case !c.CDC.Enabled:
+			return errors.New("invalid configuration: cdc.deletes.enabled requires cdc.enabled")
+		case c.CDC.Deletes.StateDir == "":
+			return errors.New("invalid configuration: cdc.deletes.stateDir is required if cdc.deletes.enabled is true")
+		case c.CDC.Deletes.ScanInterval <= 0:
+			return errors.New("invalid configuration: cdc.deletes.scanInterval must be greater than 0")
+		case c.Where != "":
+			// scans can't be filtered, deletes of objects that were never
+			// read would be emitted
+			return errors.New("invalid configuration: cdc.deletes.enabled can't be combined with where")
+		}
+	}
+
+	if c.TLS.IsSet() {
+		u, err := c.EndpointURL()
+		if err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+		if u.Scheme != "https" {
+			return fmt.Errorf("invalid configuration: %w", config.ErrTLSWithoutHTTPS)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,461 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+const (
+	// idsSuffix is the suffix of the file with the sorted IDs found by the
+	// last committed scan of a class.
+	idsSuffix = ".ids"
+	// nextSuffix is the suffix of the file with the sorted IDs found by a
+	// scan that isn't committed yet.
+	nextSuffix = ".ids.next"
+	// deletedSuffix is the suffix of the file with the IDs a scan that isn't
+	// committed yet found to be deleted.
+	deletedSuffix = ".deleted"
+)
+
+// deleteDetector detects deleted objects by periodically scanning the IDs of
+// a class or tenant, which are returned sorted, and merging them with the sorted IDs
+// stored by the previous scan. Only a page of IDs is held in memory, the IDs
+// found and the IDs of deleted objects are written to files.
+//
+// A scan is committed, i.e. its IDs replace the IDs of the previous scan,
+// once the record of the last deleted object is acknowledged, so that deletes
+// are emitted again if the source is restarted before that.
+type deleteDetector struct {
+	config DeletesConfig
+	client *weaviate.Client
+
+	lastScan time.Time
+	// emitted contains per collection the IDs of the objects emitted as
+	// snapshot records, creates or updates since the last scan, so that
+	// objects created and deleted between two scans are detected too, also
+	// if the create was emitted as an update, e.g. because the object was
+	// updated right after, or read by the snapshot after the initial scan.
+	emitted map[collection]map[string]bool
+
+	// queue contains the collections with deleted objects not emitted yet.
+	queue   []collection
+	file    *os.File
+	scanner *bufio.Scanner
+
+	mu sync.Mutex
+	// pending maps collections with an uncommitted scan to the ID of the last
+	// deleted object.
+	pending map[collection]string
+}
+
+func newDeleteDetector(config DeletesConfig, client *weaviate.Client) *deleteDetector {
+	return &deleteDetector{
+		config:  config,
+		client:  client,
+		emitted: make(map[collection]map[string]bool),
+		pending: make(map[collection]string),
+	}
+}
+
+// open removes scans that weren't committed and runs an initial scan of the
+// collections without stored IDs. Scans that weren't committed are run again
+// after the scan interval.
+func (d *deleteDetector) open(ctx context.Context, collections []collection) error {
+	err := os.MkdirAll(d.config.StateDir, 0o700)
+	if err != nil {
+		return fmt.Errorf("error creating state directory: %w", err)
+	}
+
+	for _, c := range collections {
+		for _, suffix := range []string{nextSuffix, deletedSuffix} {
+			err := os.Remove(d.path(c, suffix))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("error removing uncommitted scan of %v: %w", c.key(), err)
+			}
+		}
+
+		_, err := os.Stat(d.path(c, idsSuffix))
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error reading stored IDs of %v: %w", c.key(), err)
+		}
+
+		sdk.Logger(ctx).Info().Str("class", c.class).Str("tenant", c.tenant).Msg("scanning IDs of class")
+		_, _, err = d.scan(ctx, c)
+		if err != nil {
+			return err
+		}
+		err = d.commit(c)
+		if err != nil {
+			return err
+		}
+	}
+	d.lastScan = time.Now()
+
+	return nil
+}
+
+// due returns true if all collections should be scanned again.
+func (d *deleteDetector) due() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.queue) == 0 &&
+		len(d.pending) == 0 &&
+		time.Since(d.lastScan) >= d.config.ScanInterval
+}
+
+// scanAll scans all collections. Scans without deleted objects are committed
+// right away, other collections are queued for emitting their deletes.
+func (d *deleteDetector) scanAll(ctx context.Context, collections []collection) error {
+	for _, c := range collections {
+		deleted, last, err := d.scan(ctx, c)
+		if err != nil {
+			return err
+		}
+		sdk.Logger(ctx).Debug().
+			Str("class", c.class).
+			Str("tenant", c.tenant).
+			Int("deleted", deleted).
+			Msg("scanned IDs of class")
+
+		if deleted == 0 {
+			err = d.commit(c)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		d.mu.Lock()
+		d.pending[c] = last
+		d.mu.Unlock()
+		d.queue = append(d.queue, c)
+	}
+
+	d.emitted = make(map[collection]map[string]bool)
+	d.lastScan = time.Now()
+
+	return nil
+}
+
+// scan writes the IDs of the collection to the next file and the IDs found by
+// the previous scan, or emitted since, that don't exist anymore to the
+// deleted file. It returns the number of deleted objects and the last deleted
+// ID.
+func (d *deleteDetector) scan(ctx context.Context, c collection) (deleted int, last string, err error) {
+	prevFile, err := os.Open(d.path(c, idsSuffix))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, "", fmt.Errorf("error opening stored IDs of %v: %w", c.key(), err)
+	}
+	var prevReader io.Reader
+	if prevFile != nil {
+		defer prevFile.Close()
+		prevReader = prevFile
+	}
+
+	emitted := slices.Sorted(maps.Keys(d.emitted[c]))
+	prev := newIDIterator(prevReader, emitted)
+
+	nextFile, err := newIDWriter(d.path(c, nextSuffix))
+	if err != nil {
+		return 0, "", err
+	}
+	defer nextFile.Close()
+	deletedFile, err := newIDWriter(d.path(c, deletedSuffix))
+	if err != nil {
+		return 0, "", err
+	}
+	defer deletedFile.Close()
+
+	markDeleted := func(id string) error {
+		deleted++
+		last = id
+		return deletedFile.Write(id)
+	}
+
+	old, hasOld := prev.Next()
+	after := ""
+	for {
+		ids, err := d.client.IDs(ctx, c.class, c.tenant, after, d.config.FetchSize)
+		if err != nil {
+			return 0, "", fmt.Errorf("error scanning IDs of %v: %w", c.key(), err)
+		}
+
+		for _, id := range ids {
+			for hasOld && old < id {
+				err = markDeleted(old)
+				if err != nil {
+					return 0, "", err
+				}
+				old, hasOld = prev.Next()
+			}
+			if hasOld && old == id {
+				old, hasOld = prev.Next()
+			}
+
+			err = nextFile.Write(id)
+			if err != nil {
+				return 0, "", err
+			}
+		}
+
+		if len(ids) < d.config.FetchSize {
+			break
+		}
+		after = ids[len(ids)-1]
+	}
+	for hasOld {
+		err = markDeleted(old)
+		if err != nil {
+			return 0, "", err
+		}
+		old, hasOld = prev.Next()
+	}
+	if err := prev.Err(); err != nil {
+		return 0, "", fmt.Errorf("error reading stored IDs of %v: %w", c.key(), err)
+	}
+
+	err = nextFile.Close()
+	if err != nil {
+		return 0, "", err
+	}
+	err = deletedFile.Close()
+	if err != nil {
+		return 0, "", err
+	}
+
+	return deleted, last, nil
+}
+
+// next returns the collection and ID of the next deleted object, or false if
+// all deletes found by the last scan were returned.
+func (d *deleteDetector) next() (c collection, id string, ok bool, err error) {
+	for len(d.queue) > 0 {
+		if d.scanner == nil {
+			d.file, err = os.Open(d.path(d.queue[0], deletedSuffix))
+			if err != nil {
+				return collection{}, "", false, fmt.Errorf("error opening deleted IDs: %w", err)
+			}
+			d.scanner = bufio.NewScanner(d.file)
+		}
+
+		if d.scanner.Scan() {
+			return d.queue[0], d.scanner.Text(), true, nil
+		}
+		if err := d.scanner.Err(); err != nil {
+			return collection{}, "", false, fmt.Errorf("error reading deleted IDs: %w", err)
+		}
+
+		_ = d.file.Close()
+		d.file, d.scanner = nil, nil
+		d.queue = d.queue[1:]
+	}
+
+	return collection{}, "", false, nil
+}
+
+// emit records that a snapshot record, create or update of an object of the
+// collection was emitted.
+func (d *deleteDetector) emit(c collection, id string) {
+	if d.emitted[c] == nil {
+		d.emitted[c] = make(map[string]bool)
+	}
+	d.emitted[c][id] = true
+}
+
+// ack commits the scan of the collection if id is its last deleted object.
+func (d *deleteDetector) ack(c collection, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if last, ok := d.pending[c]; !ok || last != id {
+		return nil
+	}
+	delete(d.pending, c)
+
+	return d.commit(c)
+}
+
+// commit replaces the stored IDs of the collection with the IDs of the last
+// scan.
+func (d *deleteDetector) commit(c collection) error {
+	err := os.Rename(d.path(c, nextSuffix), d.path(c, idsSuffix))
+	if err != nil {
+		return fmt.Errorf("error committing scan of %v: %w", c.key(), err)
+	}
+
+	err = os.Remove(d.path(c, deletedSuffix))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error committing scan of %v: %w", c.key(), err)
+	}
+
+	return nil
+}
+
+// path returns the path of a file of the collection. Class and tenant names
+// can't contain dots, so the key is unique.
+func (d *deleteDetector) path(c collection, suffix string) string {
+	return filepath.Join(d.config.StateDir, c.key()+suffix)
+}
+
+func (d *deleteDetector) Close() error {
+	if d.file == nil {
+		return nil
+	}
+	return d.file.Close()
+}
+
+// readDeletes returns up to n delete records, scanning the collections first
+// if the scan interval passed. It returns no records if there are no deletes.
+func (s *Source) readDeletes(ctx context.Context, n int) ([]opencdc.Record, error) {
+	if s.deletes.due() {
+		err := s.deletes.scanAll(ctx, s.collections)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var records []opencdc.Record
+	for len(records) < n {
+		c, id, ok, err := s.deletes.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		pos := s.position
+		pos.Class = c.class
+		pos.Tenant = c.tenant
+		pos.Deleted = id
+		records = append(records, sdk.Util.Source.NewRecordDelete(
+			pos.ToSDKPosition(),
+			deleteMetadata(c),
+			opencdc.RawData(id),
+			nil,
+		))
+	}
+
+	return records, nil
+}
+
+// idIterator returns the sorted union of the IDs read from a sorted file, one
+// per line, and a sorted slice.
+type idIterator struct {
+	scanner *bufio.Scanner
+	line    string
+	hasLine bool
+	extra   []string
+}
+
+func newIDIterator(r io.Reader, extra []string) *idIterator {
+	it := &idIterator{extra: extra}
+	if r != nil {
+		it.scanner = bufio.NewScanner(r)
+		it.readLine()
+	}
+	return it
+}
+
+func (it *idIterator) readLine() {
+	it.hasLine = it.scanner.Scan()
+	it.line = it.scanner.Text()
+}
+
+// Next returns the next ID in order, or false if there are no more IDs. IDs
+// contained multiple times are returned once.
+func (it *idIterator) Next() (string, bool) {
+	var id string
+	switch {
+	case it.hasLine && (len(it.extra) == 0 || it.line <= it.extra[0]):
+		id = it.line
+		it.readLine()
+	case len(it.extra) > 0:
+		id = it.extra[0]
+		it.extra = it.extra[1:]
+	default:
+		return "", false
+	}
+
+	for len(it.extra) > 0 && it.extra[0] == id {
+		it.extra = it.extra[1:]
+	}
+	return id, true
+}
+
+func (it *idIterator) Err() error {
+	if it.scanner == nil {
+		return nil
+	}
+	return it.scanner.Err()
+}
+
+// idWriter writes IDs to a file, one per line.
+type idWriter struct {
+	file   *os.File
+	w      *bufio.Writer
+	closed bool
+}
+
+func newIDWriter(path string) (*idWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %v: %w", path, err)
+	}
+	return &idWriter{file: f, w: bufio.NewWriter(f)}, nil
+}
+
+func (w *idWriter) Write(id string) error {
+	_, err := w.w.WriteString(id + "\n")
+	if err != nil {
+		return fmt.Errorf("error writing %v: %w", w.file.Name(), err)
+	}
+	return nil
+}
+
+// Close flushes and closes the file. Closing it again is a no-op.
+func (w *idWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	err := w.w.Flush()
+	if err != nil {
+		_ = w.file.Close()
+		return fmt.Errorf("error writing %v: %w", w.file.Name(), err)
+	}
+	return w.file.Close()
+}
@@ -0,0 +1,74 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// recordMetadata returns the metadata of a record created from obj. The
+// metadata fields are the ones read by the destination, so that writing the
+// record to another Weaviate instance reproduces the object.
+func recordMetadata(obj *weaviate.Object) opencdc.Metadata {
+	metadata := opencdc.Metadata{
+		opencdc.MetadataCollection:          obj.Class,
+		weaviate.MetadataClass:              obj.Class,
+		weaviate.MetadataCreationTimeUnix:   strconv.FormatInt(obj.CreationTimeUnix, 10),
+		weaviate.MetadataLastUpdateTimeUnix: strconv.FormatInt(obj.LastUpdateTimeUnix, 10),
+	}
+	if len(obj.Vector) > 0 {
+		metadata[weaviate.MetadataVector] = formatVector(obj.Vector)
+	}
+	for name, vector := range obj.Vectors {
+		metadata[weaviate.MetadataVectorsPrefix+name] = formatVector(vector)
+	}
+	if obj.Tenant != "" {
+		metadata[weaviate.MetadataTenant] = obj.Tenant
+	}
+
+	return metadata
+}
+
+// deleteMetadata returns the metadata of a delete record of an object of the
+// collection.
+func deleteMetadata(c collection) opencdc.Metadata {
+	metadata := opencdc.Metadata{
+		opencdc.MetadataCollection: c.class,
+		weaviate.MetadataClass:     c.class,
+	}
+	if c.tenant != "" {
+		metadata[weaviate.MetadataTenant] = c.tenant
+	}
+
+	return metadata
+}
+
+// formatVector formats a vector as comma separated numbers, the format parsed
+// by the destination. The numbers are formatted with the precision needed to
+// parse them back to the same float32.
+func formatVector(vector []float32) string {
+	var sb strings.Builder
+	for i, v := range vector {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	return sb.String()
+}
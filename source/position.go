@@ -0,0 +1,126 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	ModeSnapshot = "snapshot"
+	ModeCDC      = "cdc"
+)
+
+// Position is the position of a record read by the source. The snapshot reads
+// the classes one after the other, ordered by object ID, so a class and an ID
+// are enough to resume reading. Changes are read ordered by update time and
+// ID, starting at a cursor per class.
+type Position struct {
+	// Mode is either ModeSnapshot or ModeCDC. An empty mode is a snapshot
+	// position.
+	Mode string `json:"mode,omitempty"`
+	// Class is the class the snapshot or delete record was read from.
+	Class string `json:"class,omitempty"`
+	// Tenant is the tenant the snapshot or delete record was read from, if
+	// the class has multi-tenancy enabled.
+	Tenant string `json:"tenant,omitempty"`
+	// After is the ID of the object the snapshot record was created from.
+	// Reading is resumed with the object following it.
+	After string `json:"after,omitempty"`
+	// SnapshotCursor is set instead of After if a where filter is configured,
+	// in which case the snapshot is read in order of update time, up to the
+	// cursor of the class in Cursors.
+	SnapshotCursor *Cursor `json:"snapshotCursor,omitempty"`
+	// Cursors contains the last change read from each class, or tenant of a
+	// class, keyed by the class, followed by a dot and the tenant for classes
+	// with multi-tenancy. Changes made after the snapshot started are read
+	// starting at these cursors.
+	Cursors map[string]Cursor `json:"cursors,omitempty"`
+	// Deleted is the ID of the object the delete record was created for.
+	Deleted string `json:"deleted,omitempty"`
+}
+
+// Cursor identifies the last change read from a class.
+type Cursor struct {
+	// Time is the update time of the object, in Unix milliseconds.
+	Time int64 `json:"time"`
+	// ID is the ID of the object. Objects updated at Time with an ID less
+	// than or equal to ID were already read.
+	ID string `json:"id,omitempty"`
+}
+
+// After returns true if obj was updated after the cursor.
+func (c Cursor) After(obj *weaviate.Object) bool {
+	return obj.LastUpdateTimeUnix > c.Time ||
+		(obj.LastUpdateTimeUnix == c.Time && obj.ID > c.ID)
+}
+
+// ParsePosition parses a position returned by ToSDKPosition. An empty
+// position is parsed as the zero Position, which starts at the beginning of
+// the first class.
+func ParsePosition(p opencdc.Position) (Position, error) {
+	var pos Position
+	if len(p) == 0 {
+		return pos, nil
+	}
+
+	err := json.Unmarshal(p, &pos)
+	if err != nil {
+		return Position{}, fmt.Errorf("invalid position: %w", err)
+	}
+
+	return pos, nil
+}
+
+// collection is a class, or a tenant of a class with multi-tenancy enabled,
+// which is read with its own cursor.
+type collection struct {
+	class  string
+	tenant string
+}
+
+// key returns the key of the collection in Position.Cursors.
+func (c collection) key() string {
+	if c.tenant == "" {
+		return c.class
+	}
+	return c.class + "." + c.tenant
+}
+
+// withCursor returns a copy of the position with the cursor of coll set.
+func (p Position) withCursor(coll collection, c Cursor) Position {
+	cursors := make(map[string]Cursor, len(p.Cursors))
+	for k, v := range p.Cursors {
+		cursors[k] = v
+	}
+	cursors[coll.key()] = c
+	p.Cursors = cursors
+
+	return p
+}
+
+func (p Position) ToSDKPosition() opencdc.Position {
+	bytes, err := json.Marshal(p)
+	if err != nil {
+		// a position can always be marshaled
+		panic(fmt.Errorf("error marshaling position: %w", err))
+	}
+
+	return bytes
+}
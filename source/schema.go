@@ -0,0 +1,347 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/conduitio/conduit-connector-sdk/schema"
+)
+
+// errUnknownProperty is returned when an object contains a property that
+// isn't defined in the class, which means the class changed since its schema
+// was created.
+var errUnknownProperty = errors.New("unknown property")
+
+// converter converts a non-nil property value, as decoded from JSON, to the
+// value matching its Avro type.
+type converter func(any) (any, error)
+
+// classSchema is the Avro schema of the payload of records read from a class.
+type classSchema struct {
+	schema  schema.Schema
+	convert converter
+}
+
+// attachSchema converts the payload of the record to match the schema of the
+// class and attaches the schema. The schema is created when the first record
+// of a class is read, and again if the class changed.
+func (s *Source) attachSchema(ctx context.Context, class string, record *opencdc.Record) error {
+	payload, ok := record.Payload.After.(opencdc.StructuredData)
+	if !ok {
+		return nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		sch, ok := s.schemas[class]
+		if !ok || attempt > 0 {
+			var err error
+			sch, err = s.createSchema(ctx, class)
+			if err != nil {
+				return err
+			}
+			s.schemas[class] = sch
+		}
+
+		converted, err := sch.convert(map[string]any(payload))
+		if errors.Is(err, errUnknownProperty) && attempt == 0 {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error converting payload to the schema of class %v: %w", class, err)
+		}
+
+		record.Payload.After = opencdc.StructuredData(converted.(map[string]any))
+		schema.AttachPayloadSchemaToRecord(*record, sch.schema)
+		return nil
+	}
+}
+
+// createSchema creates the Avro schema of the class from its definition and
+// registers it with the schema service.
+func (s *Source) createSchema(ctx context.Context, class string) (*classSchema, error) {
+	cls, err := s.client.Class(ctx, class)
+	if err != nil {
+		return nil, fmt.Errorf("error getting class %v: %w", class, err)
+	}
+
+	avroType, convert := avroRecord(class, cls.Properties, false)
+	bytes, err := json.Marshal(avroType)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding schema of class %v: %w", class, err)
+	}
+
+	sch, err := schema.Create(ctx, schema.TypeAvro, class+".payload", bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error creating schema of class %v: %w", class, err)
+	}
+
+	sdk.Logger(ctx).Info().
+		Str("class", class).
+		Str("subject", sch.Subject).
+		Int("version", sch.Version).
+		Msg("created payload schema")
+
+	return &classSchema{schema: sch, convert: convert}, nil
+}
+
+// avroRecord returns an Avro record type with a field for each property.
+// Properties with unsupported data types are left out of the record and
+// removed from the values.
+//
+// Fields are nullable, except arrays and records in nested records (i.e.
+// nested properties of object properties): union values in nullable records
+// can't be decoded, so these are always set, to an empty array or a record
+// with null fields if the property is missing.
+func avroRecord(name string, properties []weaviate.Property, nested bool) (map[string]any, converter) {
+	fields := make([]map[string]any, 0, len(properties))
+	converters := make(map[string]converter, len(properties))
+	// required maps the fields that aren't nullable to their empty values
+	required := make(map[string]any)
+	for _, p := range properties {
+		typ, convert := avroType(name+"_"+p.Name, p)
+		if typ == nil {
+			converters[p.Name] = nil
+			continue
+		}
+
+		if nested && !isPrimitive(typ) {
+			fields = append(fields, map[string]any{
+				"name": p.Name,
+				"type": typ,
+			})
+			converters[p.Name] = convert
+			required[p.Name] = map[string]any{}
+			if typ.(map[string]any)["type"] == "array" {
+				required[p.Name] = []any{}
+			}
+			continue
+		}
+
+		fields = append(fields, map[string]any{
+			"name":    p.Name,
+			"type":    []any{"null", typ},
+			"default": nil,
+		})
+		converters[p.Name] = nullable(typ, convert)
+	}
+
+	record := map[string]any{
+		"type":   "record",
+		"name":   name,
+		"fields": fields,
+	}
+	convert := func(v any) (any, error) {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", v)
+		}
+
+		out := make(map[string]any, len(m))
+		for k, val := range m {
+			convert, ok := converters[k]
+			if !ok {
+				return nil, fmt.Errorf("%w %v", errUnknownProperty, k)
+			}
+			if convert == nil || val == nil {
+				continue
+			}
+
+			c, err := convert(val)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+			out[k] = c
+		}
+		for k, empty := range required {
+			if _, ok := out[k]; ok {
+				continue
+			}
+			c, err := converters[k](empty)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+			out[k] = c
+		}
+		return out, nil
+	}
+
+	return record, convert
+}
+
+// isPrimitive returns true if the Avro type isn't an array or a record.
+func isPrimitive(typ any) bool {
+	m, ok := typ.(map[string]any)
+	return !ok || (m["type"] != "array" && m["type"] != "record")
+}
+
+// nullable wraps a converter of a type used in a union with null. Values of
+// record types in unions need to be wrapped in a map with the name of the
+// record.
+func nullable(typ any, convert converter) converter {
+	var recordName string
+	if m, ok := typ.(map[string]any); ok && m["type"] == "record" {
+		recordName, _ = m["name"].(string)
+	}
+
+	return func(v any) (any, error) {
+		c, err := convert(v)
+		if err != nil || recordName == "" {
+			return c, err
+		}
+		return map[string]any{recordName: c}, nil
+	}
+}
+
+// avroType returns the Avro type of a property and the converter of its
+// values, or nil if the data type isn't supported. name is used to name
+// record types.
+func avroType(name string, p weaviate.Property) (any, converter) {
+	if len(p.DataType) == 0 {
+		return nil, nil
+	}
+
+	dataType := p.DataType[0]
+	if isCrossReference(dataType) {
+		return avroArray(avroRecord(name, []weaviate.Property{
+			{Name: "beacon", DataType: []string{"text"}},
+			{Name: "href", DataType: []string{"text"}},
+		}, true))
+	}
+
+	if item, ok := strings.CutSuffix(dataType, "[]"); ok {
+		typ, convert := avroType(name, weaviate.Property{
+			Name:             p.Name,
+			DataType:         []string{item},
+			NestedProperties: p.NestedProperties,
+		})
+		if typ == nil {
+			return nil, nil
+		}
+		return avroArray(typ, convert)
+	}
+
+	switch dataType {
+	case "text", "string", "blob":
+		return "string", toString
+	case "uuid":
+		return map[string]any{"type": "string", "logicalType": "uuid"}, toString
+	case "int":
+		return "long", toInt
+	case "number":
+		return "double", toFloat
+	case "boolean":
+		return "boolean", toBool
+	case "date":
+		return map[string]any{"type": "long", "logicalType": "timestamp-micros"}, toTime
+	case "geoCoordinates":
+		return avroRecord(name, []weaviate.Property{
+			{Name: "latitude", DataType: []string{"number"}},
+			{Name: "longitude", DataType: []string{"number"}},
+		}, true)
+	case "phoneNumber":
+		return avroRecord(name, []weaviate.Property{
+			{Name: "input", DataType: []string{"text"}},
+			{Name: "defaultCountry", DataType: []string{"text"}},
+			{Name: "internationalFormatted", DataType: []string{"text"}},
+			{Name: "countryCode", DataType: []string{"int"}},
+			{Name: "national", DataType: []string{"int"}},
+			{Name: "nationalFormatted", DataType: []string{"text"}},
+			{Name: "valid", DataType: []string{"boolean"}},
+		}, true)
+	case "object":
+		return avroRecord(name, p.NestedProperties, true)
+	default:
+		return nil, nil
+	}
+}
+
+// isCrossReference returns true if the data type is a class name, i.e. the
+// property references objects of that class.
+func isCrossReference(dataType string) bool {
+	return dataType != "" && dataType[0] >= 'A' && dataType[0] <= 'Z'
+}
+
+func avroArray(items any, convert converter) (any, converter) {
+	return map[string]any{"type": "array", "items": items}, func(v any) (any, error) {
+		values, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an array, got %T", v)
+		}
+
+		out := make([]any, len(values))
+		for i, val := range values {
+			c, err := convert(val)
+			if err != nil {
+				return nil, fmt.Errorf("item %v: %w", i, err)
+			}
+			out[i] = c
+		}
+		return out, nil
+	}
+}
+
+func toString(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string, got %T", v)
+	}
+	return s, nil
+}
+
+func toInt(v any) (any, error) {
+	f, ok := v.(float64)
+	if !ok || f != math.Trunc(f) {
+		return nil, fmt.Errorf("expected an integer, got %v", v)
+	}
+	return int64(f), nil
+}
+
+func toFloat(v any) (any, error) {
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("expected a number, got %T", v)
+	}
+	return f, nil
+}
+
+func toBool(v any) (any, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("expected a boolean, got %T", v)
+	}
+	return b, nil
+}
+
+func toTime(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a date, got %T", v)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+	return t, nil
+}
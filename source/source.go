@@ -0,0 +1,361 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+// Source reads all objects of the configured classes using Weaviate's cursor
+// API and emits them as snapshot records. Afterwards, if CDC is enabled, it
+// polls the classes for objects created or updated since the snapshot started.
+type Source struct {
+	sdk.UnimplementedSource
+
+	config Config
+	client *weaviate.Client
+
+	// position is the position of the last record returned by ReadN.
+	position Position
+	// wheres contains the where filter parsed for each class, nil if objects
+	// aren't filtered.
+	wheres map[string]*filters.WhereBuilder
+	// collections contains the classes and tenants read, in order.
+	collections []collection
+	// collection is the index of the collection currently read.
+	collection int
+	// idle is the number of collections polled in a row without finding
+	// changes.
+	idle int
+	// buffer contains the objects fetched, but not returned yet.
+	buffer []*weaviate.Object
+	// deletes detects deleted objects, if enabled.
+	deletes *deleteDetector
+	// schemas contains the payload schemas of the classes, if enabled.
+	schemas map[string]*classSchema
+}
+
+func New() sdk.Source {
+	return sdk.SourceWithMiddleware(&Source{})
+}
+
+func (s *Source) Config() sdk.SourceConfig {
+	return &s.config
+}
+
+func (s *Source) Open(ctx context.Context, pos opencdc.Position) error {
+	var err error
+	s.position, err = ParsePosition(pos)
+	if err != nil {
+		return err
+	}
+	if s.position.Mode == "" {
+		s.position.Mode = ModeSnapshot
+	}
+
+	if s.position.Mode == ModeSnapshot && s.position.Class != "" &&
+		!slices.Contains(s.config.Classes, s.position.Class) {
+		return fmt.Errorf("class %v from the position is not configured in classes", s.position.Class)
+	}
+
+	if s.config.Where != "" {
+		s.wheres = make(map[string]*filters.WhereBuilder, len(s.config.Classes))
+	}
+
+	err = s.config.Config.ResolveSecrets()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := weaviate.NewConfig(s.config.Config)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	s.client = &weaviate.Client{}
+	err = s.client.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	err = s.initCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	if s.position.Mode == ModeSnapshot && s.position.Class != "" {
+		s.collection = slices.Index(s.collections, collection{class: s.position.Class, tenant: s.position.Tenant})
+		if s.collection == -1 {
+			return fmt.Errorf("tenant %v of class %v from the position is not configured in tenants", s.position.Tenant, s.position.Class)
+		}
+	}
+
+	// the cursors are also where the snapshot of a filtered class ends
+	if s.config.CDC.Enabled || s.wheres != nil {
+		err = s.initCursors(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.config.Schema.Enabled {
+		s.schemas = make(map[string]*classSchema)
+	}
+
+	if s.config.CDC.Deletes.Enabled {
+		s.deletes = newDeleteDetector(s.config.CDC.Deletes, s.client)
+		err = s.deletes.open(ctx, s.collections)
+		if err != nil {
+			return err
+		}
+	}
+
+	logger := sdk.Logger(ctx).Info().Strs("classes", s.config.Classes).Str("mode", s.position.Mode)
+	if s.position.Mode == ModeSnapshot && len(s.collections) > 0 {
+		c := s.collections[s.collection]
+		logger = logger.
+			Str("class", c.class).
+			Str("tenant", c.tenant).
+			Str("after", s.position.After)
+	}
+	logger.Msg("source opened")
+
+	return nil
+}
+
+func (s *Source) ReadN(ctx context.Context, n int) ([]opencdc.Record, error) {
+	if s.position.Mode == ModeCDC {
+		if s.deletes != nil {
+			records, err := s.readDeletes(ctx, n)
+			if err != nil || len(records) > 0 {
+				return records, err
+			}
+		}
+		return s.readChanges(ctx, n)
+	}
+
+	for {
+		for len(s.buffer) == 0 {
+			if s.collection >= len(s.collections) {
+				if !s.config.CDC.Enabled {
+					return nil, sdk.ErrBackoffRetry
+				}
+
+				sdk.Logger(ctx).Info().Msg("switching to CDC mode")
+				s.position = Position{Mode: ModeCDC, Cursors: s.position.Cursors}
+				s.collection = 0
+				return s.ReadN(ctx, n)
+			}
+
+			err := s.fetch(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		records, err := s.readSnapshot(ctx, n)
+		if err != nil || len(records) > 0 {
+			return records, err
+		}
+	}
+}
+
+// readSnapshot returns up to n snapshot records created from the buffered
+// objects. Objects of a filtered class only have their ID and timestamps set,
+// so the complete object is fetched, and skipped if it was deleted since.
+func (s *Source) readSnapshot(ctx context.Context, n int) ([]opencdc.Record, error) {
+	c := s.collections[s.collection]
+
+	var records []opencdc.Record
+	for len(records) < n && len(s.buffer) > 0 {
+		obj := s.buffer[0]
+		if s.wheres != nil {
+			full, err := s.client.Object(ctx, c.class, c.tenant, obj.ID)
+			if err != nil {
+				if len(records) > 0 {
+					return records, nil
+				}
+				return nil, fmt.Errorf("error reading object %v of class %v: %w", obj.ID, c.class, err)
+			}
+			s.position.SnapshotCursor = &Cursor{Time: obj.LastUpdateTimeUnix, ID: obj.ID}
+			obj = full
+		} else {
+			s.position.After = obj.ID
+		}
+		s.position.Class = c.class
+		s.position.Tenant = c.tenant
+		s.buffer = s.buffer[1:]
+
+		if obj == nil {
+			continue
+		}
+		if s.deletes != nil {
+			s.deletes.emit(c, obj.ID)
+		}
+
+		record := sdk.Util.Source.NewRecordSnapshot(
+			s.position.ToSDKPosition(),
+			recordMetadata(obj),
+			opencdc.RawData(obj.ID),
+			opencdc.StructuredData(obj.Properties),
+		)
+		if s.schemas != nil {
+			err := s.attachSchema(ctx, obj.Class, &record)
+			if err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// fetch fills the buffer with the next objects of the current collection, or
+// moves on to the next collection if all objects were read.
+func (s *Source) fetch(ctx context.Context) error {
+	c := s.collections[s.collection]
+	resume := s.position.Class == c.class && s.position.Tenant == c.tenant
+
+	var (
+		objects []*weaviate.Object
+		err     error
+	)
+	if s.wheres == nil {
+		var after string
+		if resume {
+			after = s.position.After
+		}
+		objects, err = s.client.Objects(ctx, c.class, c.tenant, after, s.config.FetchSize)
+	} else {
+		var cursor Cursor
+		if resume && s.position.SnapshotCursor != nil {
+			cursor = *s.position.SnapshotCursor
+		}
+		end := s.position.Cursors[c.key()]
+		objects, err = s.updatedObjects(ctx, c, cursor, &end)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading class %v: %w", c.class, err)
+	}
+
+	if len(objects) == 0 {
+		sdk.Logger(ctx).Info().
+			Str("class", c.class).
+			Str("tenant", c.tenant).
+			Msg("finished reading class")
+		s.collection++
+		if s.collection == len(s.collections) {
+			sdk.Logger(ctx).Info().Msg("snapshot completed")
+		}
+		return nil
+	}
+
+	s.buffer = objects
+	return nil
+}
+
+// initCollections resolves the classes and tenants to read. It checks the
+// classes index timestamps, if needed, and parses the where filter with the
+// properties of each class.
+func (s *Source) initCollections(ctx context.Context) error {
+	s.collections = nil
+	for _, class := range s.config.Classes {
+		cls, err := s.client.Class(ctx, class)
+		if err != nil {
+			return fmt.Errorf("error checking class %v: %w", class, err)
+		}
+
+		if !cls.IndexTimestamps {
+			switch {
+			case s.config.CDC.Enabled:
+				return fmt.Errorf("class %v doesn't index timestamps (invertedIndexConfig.indexTimestamps), which is required by cdc.enabled", class)
+			case s.wheres != nil:
+				return fmt.Errorf("class %v doesn't index timestamps (invertedIndexConfig.indexTimestamps), which is required by where", class)
+			}
+		}
+
+		if s.wheres != nil {
+			s.wheres[class], err = parseWhere(s.config.Where, cls.Properties)
+			if err != nil {
+				return fmt.Errorf("invalid configuration: %w", err)
+			}
+		}
+
+		if !cls.MultiTenancy {
+			s.collections = append(s.collections, collection{class: class})
+			continue
+		}
+
+		tenants := s.config.Tenants
+		switch {
+		case len(tenants) == 0:
+			return fmt.Errorf("class %v has multi-tenancy enabled, tenants must be configured", class)
+		case len(tenants) == 1 && tenants[0] == "*":
+			tenants, err = s.client.Tenants(ctx, class)
+			if err != nil {
+				return fmt.Errorf("error getting tenants of class %v: %w", class, err)
+			}
+		}
+		for _, tenant := range tenants {
+			s.collections = append(s.collections, collection{class: class, tenant: tenant})
+		}
+	}
+
+	return nil
+}
+
+func (s *Source) Ack(ctx context.Context, pos opencdc.Position) error {
+	sdk.Logger(ctx).Trace().Str("position", string(pos)).Msg("got ack")
+	if s.deletes == nil {
+		return nil
+	}
+
+	p, err := ParsePosition(pos)
+	if err != nil {
+		return err
+	}
+	if p.Deleted == "" {
+		return nil
+	}
+
+	return s.deletes.ack(collection{class: p.Class, tenant: p.Tenant}, p.Deleted)
+}
+
+func (s *Source) Teardown(context.Context) error {
+	if s.deletes != nil {
+		err := s.deletes.Close()
+		if err != nil {
+			return fmt.Errorf("error closing deleted IDs: %w", err)
+		}
+	}
+	if s.client == nil {
+		return nil
+	}
+
+	err := s.client.Close()
+	if err != nil {
+		return fmt.Errorf("error closing client: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,1096 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	weaviateConn "github.com/conduitio-labs/conduit-connector-weaviate"
+	"github.com/conduitio-labs/conduit-connector-weaviate/destination"
+	"github.com/conduitio-labs/conduit-connector-weaviate/destination/mock"
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/conduitio-labs/conduit-connector-weaviate/source"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/conduitio/conduit-connector-sdk/schema"
+	"github.com/matryer/is"
+	"go.uber.org/mock/gomock"
+)
+
+type object struct {
+	ID                 string               `json:"id"`
+	Class              string               `json:"class"`
+	Properties         map[string]any       `json:"properties"`
+	Vector             []float32            `json:"vector,omitempty"`
+	Vectors            map[string][]float32 `json:"vectors,omitempty"`
+	Tenant             string               `json:"tenant,omitempty"`
+	CreationTimeUnix   int64                `json:"creationTimeUnix"`
+	LastUpdateTimeUnix int64                `json:"lastUpdateTimeUnix"`
+}
+
+// server is a stand-in for Weaviate's REST and GraphQL API, serving objects
+// with the cursor API and ordered by update time.
+type server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]object
+	// properties contains the property definitions of the classes.
+	properties map[string][]map[string]any
+	// tenants contains the tenants of classes with multi-tenancy, mapped to
+	// their activity status.
+	tenants map[string]map[string]string
+	// queries contains the GraphQL queries received.
+	queries []string
+}
+
+var (
+	gqlClass     = regexp.MustCompile(`Get {(\w+)`)
+	gqlSince     = regexp.MustCompile(`operator: GreaterThanEqual path: \["_lastUpdateTimeUnix"\] valueText: "(\d+)"`)
+	gqlSinceTime = regexp.MustCompile(`operator: GreaterThan path: \["_lastUpdateTimeUnix"\] valueText: "(\d+)"`)
+	gqlSinceID   = regexp.MustCompile(`operator: GreaterThan path: \["id"\] valueText: "([^"]*)"`)
+	gqlEqual     = regexp.MustCompile(`operator: Equal path: \["([a-zA-Z]\w*)"\] valueText: "([^"]*)"`)
+	gqlTenant    = regexp.MustCompile(`tenant: "([^"]*)"`)
+	gqlLimit     = regexp.MustCompile(`limit: (\d+)`)
+	gqlAfter     = regexp.MustCompile(`after: "([^"]*)"`)
+)
+
+func newServer(t *testing.T, objects map[string][]object) *server {
+	s := &server{objects: objects}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Put creates or replaces an object.
+func (s *server) Put(obj object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objects := s.objects[obj.Class]
+	for i, o := range objects {
+		if o.ID == obj.ID {
+			objects[i] = obj
+			return
+		}
+	}
+	s.objects[obj.Class] = append(objects, obj)
+}
+
+// Delete deletes an object.
+func (s *server) Delete(class, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[class] = slices.DeleteFunc(s.objects[class], func(o object) bool {
+		return o.ID == id
+	})
+}
+
+func (s *server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/v1/meta":
+		_, _ = w.Write([]byte(`{"version":"1.27.0"}`))
+	case len(parts) == 3 && parts[1] == "schema":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"class":               parts[2],
+			"properties":          s.properties[parts[2]],
+			"invertedIndexConfig": map[string]any{"indexTimestamps": true},
+			"multiTenancyConfig":  map[string]any{"enabled": s.tenants[parts[2]] != nil},
+		})
+	case len(parts) == 4 && parts[1] == "schema" && parts[3] == "tenants":
+		tenants := []map[string]string{}
+		for name, status := range s.tenants[parts[2]] {
+			tenants = append(tenants, map[string]string{"name": name, "activityStatus": status})
+		}
+		_ = json.NewEncoder(w).Encode(tenants)
+	case r.URL.Path == "/v1/objects":
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		all := slices.Clone(s.objects[s.className(q.Get("class"))])
+		sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+		page := []object{}
+		for _, o := range all {
+			if o.Tenant == q.Get("tenant") && o.ID > q.Get("after") && len(page) < limit {
+				page = append(page, o)
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"objects": page})
+	case len(parts) == 4 && parts[1] == "objects":
+		for _, o := range s.objects[s.className(parts[2])] {
+			if o.ID == parts[3] && o.Tenant == r.URL.Query().Get("tenant") {
+				_ = json.NewEncoder(w).Encode(o)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case r.URL.Path == "/v1/graphql":
+		s.handleGraphQL(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// className returns the name of the class as stored, as Weaviate capitalizes
+// class names and accepts them in any case.
+func (s *server) className(class string) string {
+	for name := range s.objects {
+		if strings.EqualFold(name, class) {
+			return name
+		}
+	}
+	return class
+}
+
+func (s *server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query string `json:"query"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	s.queries = append(s.queries, req.Query)
+
+	class := s.className(gqlClass.FindStringSubmatch(req.Query)[1])
+	var tenant string
+	if m := gqlTenant.FindStringSubmatch(req.Query); m != nil {
+		tenant = m[1]
+	}
+	// objects updated before since, or at since with an ID up to sinceID,
+	// are left out
+	var since int64
+	var sinceID string
+	if m := gqlSince.FindStringSubmatch(req.Query); m != nil {
+		since, _ = strconv.ParseInt(m[1], 10, 64)
+	}
+	if m := gqlSinceTime.FindStringSubmatch(req.Query); m != nil {
+		since, _ = strconv.ParseInt(m[1], 10, 64)
+		sinceID = gqlSinceID.FindStringSubmatch(req.Query)[1]
+	}
+	limit, _ := strconv.ParseInt(gqlLimit.FindStringSubmatch(req.Query)[1], 10, 64)
+
+	var matching []object
+	for _, o := range s.objects[class] {
+		if o.Tenant != tenant || o.LastUpdateTimeUnix < since ||
+			(o.LastUpdateTimeUnix == since && sinceID != "" && o.ID <= sinceID) {
+			continue
+		}
+		if m := gqlEqual.FindStringSubmatch(req.Query); m != nil && o.Properties[m[1]] != m[2] {
+			continue
+		}
+		matching = append(matching, o)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].LastUpdateTimeUnix != matching[j].LastUpdateTimeUnix {
+			return matching[i].LastUpdateTimeUnix < matching[j].LastUpdateTimeUnix
+		}
+		return matching[i].ID < matching[j].ID
+	})
+	if strings.Contains(req.Query, "order:desc") {
+		slices.Reverse(matching)
+	}
+	if m := gqlAfter.FindStringSubmatch(req.Query); m != nil {
+		sort.Slice(matching, func(i, j int) bool { return matching[i].ID < matching[j].ID })
+		matching = slices.DeleteFunc(matching, func(o object) bool { return o.ID <= m[1] })
+	}
+
+	results := []any{}
+	for _, o := range matching {
+		if int64(len(results)) >= limit {
+			break
+		}
+		results = append(results, map[string]any{"_additional": map[string]string{
+			"id":                 o.ID,
+			"creationTimeUnix":   strconv.FormatInt(o.CreationTimeUnix, 10),
+			"lastUpdateTimeUnix": strconv.FormatInt(o.LastUpdateTimeUnix, 10),
+		}})
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"data": map[string]any{"Get": map[string]any{class: results}},
+	})
+}
+
+func openSource(ctx context.Context, t *testing.T, srv *server, pos opencdc.Position, cfg map[string]string) sdk.Source {
+	is := is.New(t)
+
+	params := map[string]string{
+		"endpoint":                           srv.URL,
+		"classes":                            "Article,Author",
+		"fetchSize":                          "2",
+		"schema.enabled":                     "false",
+		"sdk.schema.extract.key.enabled":     "false",
+		"sdk.schema.extract.payload.enabled": "false",
+	}
+	maps.Copy(params, cfg)
+
+	underTest := source.New()
+	err := sdk.Util.ParseConfig(ctx, params, underTest.Config(), weaviateConn.Connector.NewSpecification().SourceParams)
+	is.NoErr(err)
+
+	is.NoErr(underTest.Open(ctx, pos))
+	t.Cleanup(func() { _ = underTest.Teardown(ctx) })
+
+	return underTest
+}
+
+func readAll(ctx context.Context, t *testing.T, src sdk.Source) []opencdc.Record {
+	is := is.New(t)
+
+	var records []opencdc.Record
+	for {
+		recs, err := src.ReadN(ctx, 10)
+		if errors.Is(err, sdk.ErrBackoffRetry) {
+			return records
+		}
+		is.NoErr(err)
+		records = append(records, recs...)
+	}
+}
+
+func TestSource_Snapshot(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000003", Class: "Article", Properties: map[string]any{"title": "c"}},
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", Properties: map[string]any{"title": "a"}},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", Properties: map[string]any{"title": "b"}},
+		},
+		"Author": {
+			{ID: "00000000-0000-0000-0000-000000000004", Class: "Author", Properties: map[string]any{"name": "d"}},
+		},
+	})
+
+	records := readAll(ctx, t, openSource(ctx, t, srv, nil, map[string]string{"cdc.enabled": "false"}))
+	is.Equal(len(records), 4)
+
+	var got []string
+	for _, r := range records {
+		is.Equal(r.Operation, opencdc.OperationSnapshot)
+		class, err := r.Metadata.GetCollection()
+		is.NoErr(err)
+		got = append(got, class+"/"+string(r.Key.Bytes()))
+	}
+	is.Equal(got, []string{
+		"Article/00000000-0000-0000-0000-000000000001",
+		"Article/00000000-0000-0000-0000-000000000002",
+		"Article/00000000-0000-0000-0000-000000000003",
+		"Author/00000000-0000-0000-0000-000000000004",
+	})
+	is.Equal(records[0].Payload.After, opencdc.StructuredData{"title": "a"})
+
+	pos, err := source.ParsePosition(records[3].Position)
+	is.NoErr(err)
+	is.Equal(pos, source.Position{
+		Mode:  source.ModeSnapshot,
+		Class: "Author",
+		After: "00000000-0000-0000-0000-000000000004",
+	})
+}
+
+func TestSource_Resume(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article"},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article"},
+			{ID: "00000000-0000-0000-0000-000000000003", Class: "Article"},
+		},
+		"Author": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Author"},
+		},
+	})
+
+	pos := source.Position{Class: "Article", After: "00000000-0000-0000-0000-000000000002"}
+	records := readAll(ctx, t, openSource(ctx, t, srv, pos.ToSDKPosition(), map[string]string{"cdc.enabled": "false"}))
+
+	is.Equal(len(records), 2)
+	is.Equal(string(records[0].Key.Bytes()), "00000000-0000-0000-0000-000000000003")
+	is.Equal(records[1].Metadata[opencdc.MetadataCollection], "Author")
+}
+
+func TestSource_Open_UnknownClassInPosition(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	underTest := source.New()
+	err := sdk.Util.ParseConfig(ctx, map[string]string{
+		"endpoint": "localhost:8080",
+		"classes":  "Article",
+	}, underTest.Config(), weaviateConn.Connector.NewSpecification().SourceParams)
+	is.NoErr(err)
+
+	pos := source.Position{Class: "Removed", After: "00000000-0000-0000-0000-000000000001"}
+	err = underTest.Open(ctx, pos.ToSDKPosition())
+	is.True(err != nil)
+	is.Equal(err.Error(), "class Removed from the position is not configured in classes")
+}
+
+func TestSource_CDC(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+		"Author": {},
+	})
+
+	cdc := map[string]string{"cdc.enabled": "true"}
+	src := openSource(ctx, t, srv, nil, cdc)
+	snapshot := readAll(ctx, t, src)
+	is.Equal(len(snapshot), 2)
+
+	// an update and two creates with the same update time, more than fit
+	// into one page
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 2000, Properties: map[string]any{"title": "new"}})
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000004", Class: "Article", CreationTimeUnix: 2000, LastUpdateTimeUnix: 2000})
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000003", Class: "Article", CreationTimeUnix: 2000, LastUpdateTimeUnix: 2000})
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000005", Class: "Author", CreationTimeUnix: 1500, LastUpdateTimeUnix: 1500})
+
+	changes := readAll(ctx, t, src)
+	var got []string
+	for _, r := range changes {
+		got = append(got, r.Operation.String()+" "+r.Metadata[opencdc.MetadataCollection]+"/"+string(r.Key.Bytes()))
+	}
+	is.Equal(got, []string{
+		"update Article/00000000-0000-0000-0000-000000000001",
+		"create Article/00000000-0000-0000-0000-000000000003",
+		"create Author/00000000-0000-0000-0000-000000000005",
+		"create Article/00000000-0000-0000-0000-000000000004",
+	})
+	is.Equal(changes[0].Payload.After, opencdc.StructuredData{"title": "new"})
+
+	// polling again doesn't return objects updated at the same time again
+	is.Equal(len(readAll(ctx, t, src)), 0)
+
+	// a restarted source resumes after the object with the same update time
+	restarted := openSource(ctx, t, srv, changes[2].Position, cdc)
+	changes = readAll(ctx, t, restarted)
+	is.Equal(len(changes), 1)
+	is.Equal(string(changes[0].Key.Bytes()), "00000000-0000-0000-0000-000000000004")
+}
+
+func TestSource_CDC_LowerCaseClass(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+	})
+
+	src := openSource(ctx, t, srv, nil, map[string]string{"classes": "article", "cdc.enabled": "true"})
+	is.Equal(len(readAll(ctx, t, src)), 1) // snapshot
+
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", CreationTimeUnix: 2000, LastUpdateTimeUnix: 2000})
+	changes := readAll(ctx, t, src)
+	is.Equal(len(changes), 1)
+	is.Equal(string(changes[0].Key.Bytes()), "00000000-0000-0000-0000-000000000002")
+}
+
+func TestSource_CDC_ChangesDuringSnapshot(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+		"Author": {},
+	})
+
+	cdc := map[string]string{"cdc.enabled": "true"}
+	src := openSource(ctx, t, srv, nil, cdc)
+	records, err := src.ReadN(ctx, 1)
+	is.NoErr(err)
+	is.Equal(len(records), 1)
+
+	// the object was already read by the snapshot, the change is read after it
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 3000})
+
+	var got []string
+	for _, r := range append(records, readAll(ctx, t, src)...) {
+		got = append(got, r.Operation.String()+" "+string(r.Key.Bytes()))
+	}
+	is.Equal(got, []string{
+		"snapshot 00000000-0000-0000-0000-000000000001",
+		"snapshot 00000000-0000-0000-0000-000000000002",
+		"update 00000000-0000-0000-0000-000000000001",
+	})
+}
+
+func TestSource_Deletes(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000003", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+		"Author": {},
+	})
+	cfg := map[string]string{
+		"cdc.enabled":              "true",
+		"cdc.deletes.enabled":      "true",
+		"cdc.deletes.stateDir":     t.TempDir(),
+		"cdc.deletes.scanInterval": "1ms",
+		"cdc.deletes.fetchSize":    "2",
+	}
+	ops := func(records []opencdc.Record) []string {
+		var got []string
+		for _, r := range records {
+			got = append(got, r.Operation.String()+" "+string(r.Key.Bytes()))
+		}
+		return got
+	}
+	read := func(src sdk.Source) []opencdc.Record {
+		time.Sleep(5 * time.Millisecond) // let the scan interval pass
+		return readAll(ctx, t, src)
+	}
+
+	src := openSource(ctx, t, srv, nil, cfg)
+	is.Equal(len(read(src)), 3) // snapshot
+
+	srv.Delete("Article", "00000000-0000-0000-0000-000000000002")
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000004", Class: "Article", CreationTimeUnix: 2000, LastUpdateTimeUnix: 2000})
+	records := read(src)
+	is.Equal(ops(records), []string{
+		"delete 00000000-0000-0000-0000-000000000002",
+		"create 00000000-0000-0000-0000-000000000004",
+	})
+	is.Equal(records[0].Metadata[opencdc.MetadataCollection], "Article")
+
+	// no scan runs until the delete is acknowledged, an object created in
+	// the meantime is read as an update if it was updated right after
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000005", Class: "Article", CreationTimeUnix: 3000, LastUpdateTimeUnix: 3500})
+	is.Equal(ops(read(src)), []string{"update 00000000-0000-0000-0000-000000000005"})
+	is.NoErr(src.Ack(ctx, records[0].Position))
+
+	// the object emitted since the last scan is detected as deleted too
+	srv.Delete("Article", "00000000-0000-0000-0000-000000000005")
+	records = read(src)
+	is.Equal(ops(records), []string{"delete 00000000-0000-0000-0000-000000000005"})
+	is.NoErr(src.Ack(ctx, records[0].Position))
+
+	srv.Delete("Article", "00000000-0000-0000-0000-000000000001")
+	srv.Delete("Article", "00000000-0000-0000-0000-000000000004")
+	records = read(src)
+	is.Equal(ops(records), []string{
+		"delete 00000000-0000-0000-0000-000000000001",
+		"delete 00000000-0000-0000-0000-000000000004",
+	})
+
+	// deletes that weren't acknowledged are emitted again after a restart
+	restarted := openSource(ctx, t, srv, records[1].Position, cfg)
+	records = read(restarted)
+	is.Equal(ops(records), []string{
+		"delete 00000000-0000-0000-0000-000000000001",
+		"delete 00000000-0000-0000-0000-000000000004",
+	})
+	is.NoErr(restarted.Ack(ctx, records[0].Position))
+	is.NoErr(restarted.Ack(ctx, records[1].Position))
+
+	is.Equal(len(read(restarted)), 0)
+}
+
+func TestSource_Deletes_CreatedDuringSnapshot(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+		"Author": {},
+	})
+	cfg := map[string]string{
+		"cdc.enabled":              "true",
+		"cdc.deletes.enabled":      "true",
+		"cdc.deletes.stateDir":     t.TempDir(),
+		"cdc.deletes.scanInterval": "1ms",
+	}
+
+	// the object is created after the initial scan and read by the snapshot
+	src := openSource(ctx, t, srv, nil, cfg)
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", CreationTimeUnix: 2000, LastUpdateTimeUnix: 2000})
+	records, err := src.ReadN(ctx, 2)
+	is.NoErr(err)
+	is.Equal(len(records), 2)
+	is.Equal(records[1].Operation, opencdc.OperationSnapshot)
+
+	// it's deleted before the first scan, which still detects the delete
+	srv.Delete("Article", "00000000-0000-0000-0000-000000000002")
+	time.Sleep(5 * time.Millisecond) // let the scan interval pass
+	records = readAll(ctx, t, src)
+	is.Equal(len(records), 1)
+	is.Equal(records[0].Operation, opencdc.OperationDelete)
+	is.Equal(string(records[0].Key.Bytes()), "00000000-0000-0000-0000-000000000002")
+}
+
+func TestSource_Deletes_Config(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name    string
+		cfg     map[string]string
+		wantErr string
+	}{{
+		name:    "state directory missing",
+		cfg:     map[string]string{"cdc.enabled": "true", "cdc.deletes.enabled": "true"},
+		wantErr: "cdc.deletes.stateDir is required if cdc.deletes.enabled is true",
+	}, {
+		name: "cdc disabled",
+		cfg: map[string]string{
+			"cdc.enabled":          "false",
+			"cdc.deletes.enabled":  "true",
+			"cdc.deletes.stateDir": "/tmp/ids",
+		},
+		wantErr: "cdc.deletes.enabled requires cdc.enabled",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			params := map[string]string{
+				"endpoint": "localhost:8080",
+				"classes":  "Article",
+			}
+			maps.Copy(params, tc.cfg)
+
+			err := sdk.Util.ParseConfig(ctx, params, source.New().Config(), weaviateConn.Connector.NewSpecification().SourceParams)
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.wantErr))
+		})
+	}
+}
+
+func TestSource_Metadata(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {{
+			ID:                 "00000000-0000-0000-0000-000000000001",
+			Class:              "Article",
+			Vector:             []float32{0.1, -2, 1e-7},
+			Vectors:            map[string][]float32{"title": {0.5}},
+			Tenant:             "tenantA",
+			CreationTimeUnix:   1000,
+			LastUpdateTimeUnix: 2000,
+		}},
+		"Author": {},
+	})
+	srv.tenants = map[string]map[string]string{"Article": {"tenantA": "HOT"}}
+
+	records := readAll(ctx, t, openSource(ctx, t, srv, nil, map[string]string{
+		"cdc.enabled": "false",
+		"tenants":     "tenantA",
+	}))
+	is.Equal(len(records), 1)
+
+	got := records[0].Metadata
+	delete(got, opencdc.MetadataReadAt)
+	is.Equal(got, opencdc.Metadata{
+		opencdc.MetadataCollection:               "Article",
+		weaviate.MetadataClass:                   "Article",
+		weaviate.MetadataVector:                  "0.1,-2,1e-07",
+		weaviate.MetadataVectorsPrefix + "title": "0.5",
+		weaviate.MetadataTenant:                  "tenantA",
+		weaviate.MetadataCreationTimeUnix:        "1000",
+		weaviate.MetadataLastUpdateTimeUnix:      "2000",
+	})
+}
+
+// TestSource_RoundTrip writes the records read by the source using the
+// destination and checks that the written objects are the ones read.
+func TestSource_RoundTrip(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	objects := []object{{
+		ID:         "00000000-0000-0000-0000-000000000001",
+		Class:      "Article",
+		Properties: map[string]any{"title": "a", "words": float64(120), "tags": []any{"x", "y"}},
+		Vector:     []float32{0.1, 0.2, 1e-7, -3.4028235e+38},
+		Tenant:     "tenantA",
+	}, {
+		ID:         "00000000-0000-0000-0000-000000000002",
+		Class:      "Article",
+		Properties: map[string]any{"title": "b", "published": true},
+		Vectors:    map[string][]float32{"title": {0.5, -1.25}, "body": {3}},
+		Tenant:     "tenantA",
+	}, {
+		ID:         "00000000-0000-0000-0000-000000000003",
+		Class:      "Author",
+		Properties: map[string]any{"name": "c"},
+		Vector:     []float32{0.33333334},
+	}}
+
+	srv := newServer(t, map[string][]object{
+		"Article": objects[:2],
+		"Author":  objects[2:],
+	})
+	srv.tenants = map[string]map[string]string{"Article": {"tenantA": "HOT"}}
+	srv.properties = map[string][]map[string]any{
+		"Article": {
+			{"name": "title", "dataType": []string{"text"}},
+			{"name": "words", "dataType": []string{"int"}},
+			{"name": "tags", "dataType": []string{"text[]"}},
+			{"name": "published", "dataType": []string{"boolean"}},
+		},
+		"Author": {
+			{"name": "name", "dataType": []string{"text"}},
+		},
+	}
+	records := readAll(ctx, t, openSource(ctx, t, srv, nil, map[string]string{
+		"cdc.enabled":    "false",
+		"tenants":        "tenantA",
+		"schema.enabled": "true",
+	}))
+
+	var written []*weaviate.Object
+	ctrl := gomock.NewController(t)
+	client := mock.NewWeaviateClient(ctrl)
+	client.EXPECT().Open(gomock.Any())
+	client.EXPECT().
+		Insert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, obj *weaviate.Object) error {
+			written = append(written, obj)
+			return nil
+		}).
+		Times(len(objects))
+
+	dest := destination.NewWithClient(client)
+	err := sdk.Util.ParseConfig(ctx, map[string]string{
+		"endpoint":          "localhost:8080",
+		"class":             "Other",
+		"preflight.enabled": "false",
+	}, dest.Config(), weaviateConn.Connector.NewSpecification().DestinationParams)
+	is.NoErr(err)
+	is.NoErr(dest.Open(ctx))
+
+	n, err := dest.Write(ctx, records)
+	is.NoErr(err)
+	is.Equal(n, len(objects))
+
+	for i, want := range objects {
+		// Avro doesn't distinguish missing and null properties, which
+		// Weaviate treats the same
+		maps.DeleteFunc(written[i].Properties, func(_ string, v any) bool { return v == nil })
+
+		is.Equal(written[i], &weaviate.Object{
+			ID:         want.ID,
+			Class:      want.Class,
+			Properties: want.Properties,
+			Vector:     want.Vector,
+			Vectors:    want.Vectors,
+			Tenant:     want.Tenant,
+		})
+	}
+}
+
+func TestSource_Schema(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {{
+			ID:    "00000000-0000-0000-0000-000000000001",
+			Class: "Article",
+			Properties: map[string]any{
+				"title":     "a",
+				"words":     float64(120),
+				"rating":    4.5,
+				"published": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).Format(time.RFC3339),
+				"location":  map[string]any{"latitude": 52.5, "longitude": 13.4},
+				"author": map[string]any{
+					"name": "b",
+					"tags": []any{"x", "y"},
+				},
+				"writtenBy": []any{map[string]any{"beacon": "weaviate://localhost/Author/00000000-0000-0000-0000-000000000002"}},
+			},
+		}, {
+			ID:         "00000000-0000-0000-0000-000000000002",
+			Class:      "Article",
+			Properties: map[string]any{"author": map[string]any{"name": "c"}},
+		}},
+		"Author": {},
+	})
+	srv.properties = map[string][]map[string]any{
+		"Article": {
+			{"name": "title", "dataType": []string{"text"}},
+			{"name": "words", "dataType": []string{"int"}},
+			{"name": "rating", "dataType": []string{"number"}},
+			{"name": "published", "dataType": []string{"date"}},
+			{"name": "location", "dataType": []string{"geoCoordinates"}},
+			{"name": "author", "dataType": []string{"object"}, "nestedProperties": []map[string]any{
+				{"name": "name", "dataType": []string{"text"}},
+				{"name": "tags", "dataType": []string{"text[]"}},
+			}},
+			{"name": "writtenBy", "dataType": []string{"Author"}},
+		},
+	}
+
+	records := readAll(ctx, t, openSource(ctx, t, srv, nil, map[string]string{
+		"cdc.enabled":    "false",
+		"schema.enabled": "true",
+	}))
+	is.Equal(len(records), 2)
+
+	subject, err := records[0].Metadata.GetPayloadSchemaSubject()
+	is.NoErr(err)
+	is.Equal(subject, "Article.payload")
+	version, err := records[0].Metadata.GetPayloadSchemaVersion()
+	is.NoErr(err)
+
+	sch, err := schema.Get(ctx, subject, version)
+	is.NoErr(err)
+	serde, err := sch.Serde()
+	is.NoErr(err)
+
+	var got opencdc.StructuredData
+	is.NoErr(serde.Unmarshal(records[0].Payload.After.Bytes(), &got))
+	is.Equal(got, opencdc.StructuredData{
+		"title":     "a",
+		"words":     int64(120),
+		"rating":    4.5,
+		"published": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"location":  map[string]any{"latitude": 52.5, "longitude": 13.4},
+		"author": map[string]any{
+			"name": "b",
+			"tags": []any{"x", "y"},
+		},
+		"writtenBy": []any{map[string]any{
+			"beacon": "weaviate://localhost/Author/00000000-0000-0000-0000-000000000002",
+			"href":   nil,
+		}},
+	})
+
+	// arrays in object properties aren't nullable
+	got = nil
+	is.NoErr(serde.Unmarshal(records[1].Payload.After.Bytes(), &got))
+	author := got["author"].(map[string]any)
+	is.Equal(author["name"], "c")
+	is.Equal(len(author["tags"].([]any)), 0)
+}
+
+func TestSource_Schema_ClassChanged(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", Properties: map[string]any{"title": "a"}},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", Properties: map[string]any{"title": "b", "words": float64(3)}},
+		},
+		"Author": {},
+	})
+	srv.properties = map[string][]map[string]any{
+		"Article": {{"name": "title", "dataType": []string{"text"}}},
+	}
+
+	src := openSource(ctx, t, srv, nil, map[string]string{
+		"cdc.enabled":    "false",
+		"schema.enabled": "true",
+	})
+	first, err := src.ReadN(ctx, 1)
+	is.NoErr(err)
+
+	// a property is added to the class, e.g. by auto-schema
+	srv.mu.Lock()
+	srv.properties["Article"] = append(srv.properties["Article"], map[string]any{"name": "words", "dataType": []string{"int"}})
+	srv.mu.Unlock()
+
+	second, err := src.ReadN(ctx, 1)
+	is.NoErr(err)
+
+	v1, err := first[0].Metadata.GetPayloadSchemaVersion()
+	is.NoErr(err)
+	v2, err := second[0].Metadata.GetPayloadSchemaVersion()
+	is.NoErr(err)
+	is.Equal(v2, v1+1)
+}
+
+func TestSource_Where(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	published := map[string]any{"status": "published"}
+	draft := map[string]any{"status": "draft"}
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", Properties: published, CreationTimeUnix: 3000, LastUpdateTimeUnix: 3000},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", Properties: draft, CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000003", Class: "Article", Properties: published, CreationTimeUnix: 1000, LastUpdateTimeUnix: 2000},
+			{ID: "00000000-0000-0000-0000-000000000004", Class: "Article", Properties: published, CreationTimeUnix: 1000, LastUpdateTimeUnix: 2000},
+		},
+	})
+	cfg := map[string]string{
+		"classes":     "Article",
+		"where":       `status == "published"`,
+		"cdc.enabled": "true",
+	}
+
+	src := openSource(ctx, t, srv, nil, cfg)
+	snapshot := readAll(ctx, t, src)
+
+	// the snapshot of a filtered class is read in order of update time
+	var got []string
+	for _, r := range snapshot {
+		is.Equal(r.Operation, opencdc.OperationSnapshot)
+		got = append(got, string(r.Key.Bytes()))
+	}
+	is.Equal(got, []string{
+		"00000000-0000-0000-0000-000000000003",
+		"00000000-0000-0000-0000-000000000004",
+		"00000000-0000-0000-0000-000000000001",
+	})
+	pos, err := source.ParsePosition(snapshot[0].Position)
+	is.NoErr(err)
+	is.Equal(pos.SnapshotCursor, &source.Cursor{Time: 2000, ID: "00000000-0000-0000-0000-000000000003"})
+
+	// a restarted snapshot resumes after the object with the same update time
+	restarted := openSource(ctx, t, srv, snapshot[0].Position, cfg)
+	resumed := readAll(ctx, t, restarted)
+	is.Equal(len(resumed), 2)
+	is.Equal(string(resumed[0].Key.Bytes()), "00000000-0000-0000-0000-000000000004")
+
+	// changes of objects not matching the filter aren't read
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", Properties: published, CreationTimeUnix: 1000, LastUpdateTimeUnix: 4000})
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000005", Class: "Article", Properties: draft, CreationTimeUnix: 4000, LastUpdateTimeUnix: 4000})
+
+	changes := readAll(ctx, t, src)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].Operation, opencdc.OperationUpdate)
+	is.Equal(string(changes[0].Key.Bytes()), "00000000-0000-0000-0000-000000000002")
+}
+
+func TestSource_Where_Filter(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		where      string
+		properties []map[string]any
+		want       string
+	}{{
+		name:  "condition",
+		where: `status == published`,
+		want:  `where:{operator: Equal path: ["status"] valueText: "published"}`,
+	}, {
+		name:  "conditions joined by and",
+		where: `status != "in review" and views>=100 AND score < 0.5`,
+		want: `where:{operator: And operands:[` +
+			`{operator: NotEqual path: ["status"] valueText: "in review"},` +
+			`{operator: GreaterThanEqual path: ["views"] valueInt: 100},` +
+			`{operator: LessThan path: ["score"] valueNumber: 0.5}]}`,
+	}, {
+		name:  "conditions joined by or",
+		where: `featured == true or author.Author.name like "J*"`,
+		want: `where:{operator: Or operands:[` +
+			`{operator: Equal path: ["featured"] valueBoolean: true},` +
+			`{operator: Like path: ["author","Author","name"] valueText: "J*"}]}`,
+	}, {
+		name:  "json",
+		where: `{"operator": "ContainsAny", "path": ["tags"], "valueTextArray": ["go", "weaviate"]}`,
+		want:  `where:{operator: ContainsAny path: ["tags"] valueText: ["go","weaviate"]}`,
+	}, {
+		name:  "values typed by the schema",
+		where: `score >= 1 and views < 100 and code == 42 and other == 7`,
+		properties: []map[string]any{
+			{"name": "score", "dataType": []string{"number"}},
+			{"name": "views", "dataType": []string{"int"}},
+			{"name": "code", "dataType": []string{"text"}},
+		},
+		want: `where:{operator: And operands:[` +
+			`{operator: GreaterThanEqual path: ["score"] valueNumber: 1},` +
+			`{operator: LessThan path: ["views"] valueInt: 100},` +
+			`{operator: Equal path: ["code"] valueText: "42"},` +
+			`{operator: Equal path: ["other"] valueInt: 7}]}`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			srv := newServer(t, map[string][]object{"Article": {}})
+			srv.properties = map[string][]map[string]any{"Article": tc.properties}
+			openSource(ctx, t, srv, nil, map[string]string{
+				"classes": "Article",
+				"where":   tc.where,
+			})
+
+			is.Equal(len(srv.queries), 1)
+			is.True(strings.Contains(srv.queries[0], tc.want))
+		})
+	}
+}
+
+func TestSource_Where_Config(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name    string
+		cfg     map[string]string
+		wantErr string
+	}{{
+		name:    "unknown operator",
+		cfg:     map[string]string{"where": `status = "published"`},
+		wantErr: `invalid where filter: unknown operator "="`,
+	}, {
+		name:    "incomplete condition",
+		cfg:     map[string]string{"where": `status == "published" and views`},
+		wantErr: `invalid where filter: incomplete condition at "views"`,
+	}, {
+		name:    "and combined with or",
+		cfg:     map[string]string{"where": `a == 1 and b == 2 or c == 3`},
+		wantErr: "invalid where filter: and and or can't be combined",
+	}, {
+		name:    "invalid json",
+		cfg:     map[string]string{"where": `{"path": ["status"], "valueText": "published"}`},
+		wantErr: "invalid where filter: operator is required",
+	}, {
+		name: "deletes",
+		cfg: map[string]string{
+			"where":                `status == "published"`,
+			"cdc.enabled":          "true",
+			"cdc.deletes.enabled":  "true",
+			"cdc.deletes.stateDir": "/tmp/ids",
+		},
+		wantErr: "cdc.deletes.enabled can't be combined with where",
+	}, {
+		name:    "all tenants and others",
+		cfg:     map[string]string{"tenants": "*,tenantA"},
+		wantErr: "tenants can't contain * and other tenants",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			params := map[string]string{
+				"endpoint": "localhost:8080",
+				"classes":  "Article",
+			}
+			maps.Copy(params, tc.cfg)
+
+			err := sdk.Util.ParseConfig(ctx, params, source.New().Config(), weaviateConn.Connector.NewSpecification().SourceParams)
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.wantErr))
+		})
+	}
+}
+
+func TestSource_Tenants(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	srv := newServer(t, map[string][]object{
+		"Article": {
+			{ID: "00000000-0000-0000-0000-000000000001", Class: "Article", Tenant: "tenantB", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000002", Class: "Article", Tenant: "tenantA", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+			{ID: "00000000-0000-0000-0000-000000000003", Class: "Article", Tenant: "tenantC", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+		"Author": {
+			{ID: "00000000-0000-0000-0000-000000000004", Class: "Author", CreationTimeUnix: 1000, LastUpdateTimeUnix: 1000},
+		},
+	})
+	srv.tenants = map[string]map[string]string{
+		"Article": {"tenantA": "HOT", "tenantB": "ACTIVE", "tenantC": "COLD"},
+	}
+
+	// inactive tenants are left out, classes without multi-tenancy are read
+	// as usual
+	src := openSource(ctx, t, srv, nil, map[string]string{"tenants": "*", "cdc.enabled": "true"})
+	snapshot := readAll(ctx, t, src)
+	var got []string
+	for _, r := range snapshot {
+		got = append(got, r.Metadata[opencdc.MetadataCollection]+"/"+r.Metadata[weaviate.MetadataTenant]+"/"+string(r.Key.Bytes()))
+	}
+	is.Equal(got, []string{
+		"Article/tenantA/00000000-0000-0000-0000-000000000002",
+		"Article/tenantB/00000000-0000-0000-0000-000000000001",
+		"Author//00000000-0000-0000-0000-000000000004",
+	})
+	pos, err := source.ParsePosition(snapshot[1].Position)
+	is.NoErr(err)
+	is.Equal(pos.Tenant, "tenantB")
+	is.Equal(pos.Cursors, map[string]source.Cursor{
+		"Article.tenantA": {Time: 1000, ID: "00000000-0000-0000-0000-000000000002"},
+		"Article.tenantB": {Time: 1000, ID: "00000000-0000-0000-0000-000000000001"},
+		"Author":          {Time: 1000, ID: "00000000-0000-0000-0000-000000000004"},
+	})
+
+	// each tenant has its own cursor
+	srv.Put(object{ID: "00000000-0000-0000-0000-000000000005", Class: "Article", Tenant: "tenantB", CreationTimeUnix: 2000, LastUpdateTimeUnix: 2000})
+	changes := readAll(ctx, t, src)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].Operation, opencdc.OperationCreate)
+	is.Equal(changes[0].Metadata[weaviate.MetadataTenant], "tenantB")
+	pos, err = source.ParsePosition(changes[0].Position)
+	is.NoErr(err)
+	is.Equal(pos.Cursors["Article.tenantB"], source.Cursor{Time: 2000, ID: "00000000-0000-0000-0000-000000000005"})
+
+	// a single tenant is read if listed
+	records := readAll(ctx, t, openSource(ctx, t, srv, nil, map[string]string{
+		"classes":     "Article",
+		"tenants":     "tenantC",
+		"cdc.enabled": "false",
+	}))
+	is.Equal(len(records), 1)
+	is.Equal(string(records[0].Key.Bytes()), "00000000-0000-0000-0000-000000000003")
+
+	// multi-tenant classes require tenants
+	underTest := source.New()
+	err = sdk.Util.ParseConfig(ctx, map[string]string{
+		"endpoint": srv.URL,
+		"classes":  "Article",
+	}, underTest.Config(), weaviateConn.Connector.NewSpecification().SourceParams)
+	is.NoErr(err)
+	err = underTest.Open(ctx, nil)
+	is.True(err != nil)
+	is.Equal(err.Error(), "class Article has multi-tenancy enabled, tenants must be configured")
+	is.NoErr(underTest.Teardown(ctx))
+}
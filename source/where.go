@@ -0,0 +1,266 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/conduitio-labs/conduit-connector-weaviate/internal/weaviate"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+	"github.com/weaviate/weaviate/entities/models"
+)
+
+// dslOperators maps the comparison operators of the where DSL to Weaviate's
+// operators.
+var dslOperators = map[string]filters.WhereOperator{
+	"==":   filters.Equal,
+	"!=":   filters.NotEqual,
+	">":    filters.GreaterThan,
+	">=":   filters.GreaterThanEqual,
+	"<":    filters.LessThan,
+	"<=":   filters.LessThanEqual,
+	"like": filters.Like,
+}
+
+// parseWhere parses a where filter, either a Weaviate where filter in JSON or
+// conditions of the form `<path> <operator> <value>` joined by `and` or `or`,
+// e.g. `status == "published" and views >= 100`. A path of a nested or
+// referenced property is separated by dots. Quoted values are text values.
+// The type of bare values is taken from the properties of the class, if the
+// path is a property of the class, otherwise bare values are text values,
+// except for true, false and numbers.
+func parseWhere(where string, properties []weaviate.Property) (*filters.WhereBuilder, error) {
+	where = strings.TrimSpace(where)
+	if strings.HasPrefix(where, "{") {
+		var f models.WhereFilter
+		err := json.Unmarshal([]byte(where), &f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid where filter: %w", err)
+		}
+		return whereBuilder(&f)
+	}
+
+	tokens, err := tokenizeWhere(where)
+	if err != nil {
+		return nil, fmt.Errorf("invalid where filter: %w", err)
+	}
+
+	dataTypes := make(map[string]string, len(properties))
+	for _, p := range properties {
+		if len(p.DataType) > 0 {
+			dataTypes[p.Name] = p.DataType[0]
+		}
+	}
+
+	var (
+		operands []*filters.WhereBuilder
+		join     string
+	)
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("invalid where filter: incomplete condition at %q", tokens[0].text)
+		}
+		op, ok := dslOperators[strings.ToLower(tokens[1].text)]
+		if !ok || tokens[1].quoted {
+			return nil, fmt.Errorf("invalid where filter: unknown operator %q", tokens[1].text)
+		}
+		operands = append(operands, dslCondition(tokens[0].text, op, tokens[2], dataTypes[tokens[0].text]))
+		tokens = tokens[3:]
+
+		if len(tokens) == 0 {
+			break
+		}
+		j := strings.ToLower(tokens[0].text)
+		if (j != "and" && j != "or") || tokens[0].quoted {
+			return nil, fmt.Errorf("invalid where filter: expected and or or, got %q", tokens[0].text)
+		}
+		if join != "" && j != join {
+			return nil, errors.New("invalid where filter: and and or can't be combined, use a JSON filter instead")
+		}
+		join = j
+		tokens = tokens[1:]
+	}
+
+	switch {
+	case len(operands) == 0:
+		return nil, errors.New("invalid where filter: no conditions")
+	case len(operands) == 1:
+		return operands[0], nil
+	case join == "or":
+		return filters.Where().WithOperator(filters.Or).WithOperands(operands), nil
+	default:
+		return filters.Where().WithOperator(filters.And).WithOperands(operands), nil
+	}
+}
+
+// dslCondition returns the condition of the where DSL comparing the property
+// at path with value. Quoted values are always text values. Bare values are
+// compared as the dataType of the property, e.g. as a number with a number
+// property, or as the type they look like if the data type is unknown.
+func dslCondition(path string, op filters.WhereOperator, token whereToken, dataType string) *filters.WhereBuilder {
+	w := filters.Where().WithPath(strings.Split(path, ".")).WithOperator(op)
+	value := token.text
+	if token.quoted {
+		return w.WithValueText(value)
+	}
+
+	switch strings.TrimSuffix(dataType, "[]") {
+	case "text":
+		return w.WithValueText(value)
+	case "int":
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return w.WithValueInt(i)
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return w.WithValueNumber(f)
+		}
+	}
+
+	if value == "true" || value == "false" {
+		return w.WithValueBoolean(value == "true")
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return w.WithValueInt(i)
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return w.WithValueNumber(f)
+	}
+	return w.WithValueText(value)
+}
+
+// whereToken is a path, operator, value or join of the where DSL.
+type whereToken struct {
+	text string
+	// quoted is true if the token was a quoted string.
+	quoted bool
+}
+
+// tokenizeWhere splits a filter of the where DSL into tokens.
+func tokenizeWhere(s string) ([]whereToken, error) {
+	var tokens []whereToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, errors.New("unterminated string")
+			}
+			text, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %v: %w", s[i:end+1], err)
+			}
+			tokens = append(tokens, whereToken{text: text, quoted: true})
+			i = end + 1
+		case strings.ContainsRune("=!<>", rune(c)):
+			end := i + 1
+			if end < len(s) && s[end] == '=' {
+				end++
+			}
+			tokens = append(tokens, whereToken{text: s[i:end]})
+			i = end
+		default:
+			end := i
+			for end < len(s) && !unicode.IsSpace(rune(s[end])) && !strings.ContainsRune(`"=!<>`, rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, whereToken{text: s[i:end]})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// whereBuilder converts a where filter decoded from JSON to a builder.
+func whereBuilder(f *models.WhereFilter) (*filters.WhereBuilder, error) {
+	if f.Operator == "" {
+		return nil, errors.New("invalid where filter: operator is required")
+	}
+	w := filters.Where().WithOperator(filters.WhereOperator(f.Operator))
+
+	if len(f.Operands) > 0 {
+		operands := make([]*filters.WhereBuilder, len(f.Operands))
+		for i, o := range f.Operands {
+			var err error
+			operands[i], err = whereBuilder(o)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return w.WithOperands(operands), nil
+	}
+
+	if len(f.Path) == 0 {
+		return nil, fmt.Errorf("invalid where filter: path is required by operator %v", f.Operator)
+	}
+	w = w.WithPath(f.Path)
+
+	switch {
+	case f.ValueText != nil:
+		w = w.WithValueText(*f.ValueText)
+	case f.ValueTextArray != nil:
+		w = w.WithValueText(f.ValueTextArray...)
+	case f.ValueString != nil:
+		w = w.WithValueString(*f.ValueString)
+	case f.ValueStringArray != nil:
+		w = w.WithValueString(f.ValueStringArray...)
+	case f.ValueInt != nil:
+		w = w.WithValueInt(*f.ValueInt)
+	case f.ValueIntArray != nil:
+		w = w.WithValueInt(f.ValueIntArray...)
+	case f.ValueNumber != nil:
+		w = w.WithValueNumber(*f.ValueNumber)
+	case f.ValueNumberArray != nil:
+		w = w.WithValueNumber(f.ValueNumberArray...)
+	case f.ValueBoolean != nil:
+		w = w.WithValueBoolean(*f.ValueBoolean)
+	case f.ValueBooleanArray != nil:
+		w = w.WithValueBoolean(f.ValueBooleanArray...)
+	case f.ValueDate != nil || f.ValueDateArray != nil:
+		dates := f.ValueDateArray
+		if f.ValueDate != nil {
+			dates = []string{*f.ValueDate}
+		}
+		values := make([]time.Time, len(dates))
+		for i, d := range dates {
+			var err error
+			values[i], err = time.Parse(time.RFC3339Nano, d)
+			if err != nil {
+				return nil, fmt.Errorf("invalid where filter: invalid date %q: %w", d, err)
+			}
+		}
+		w = w.WithValueDate(values...)
+	default:
+		return nil, fmt.Errorf("invalid where filter: a value is required by operator %v", f.Operator)
+	}
+
+	return w, nil
+}